@@ -0,0 +1,190 @@
+package colors
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Photoshop .aco color spaces
+const (
+	acoRGB  = 0
+	acoHSB  = 1
+	acoCMYK = 2
+	acoLab  = 7
+	acoGray = 8
+)
+
+// acoMaxNameUnits limits the length of names read from an .aco file
+const acoMaxNameUnits = 1 << 15
+
+// ReadACO reads a Photoshop .aco color swatch file. Names are read from the version 2
+// section when present. HSB swatches are converted to RGB, all swatches are process
+// swatches and grayscale swatches stored as ink coverage are inverted to gray levels
+func ReadACO(r io.Reader) ([]Swatch, error) {
+
+	var version, count uint16
+
+	if err := binary.Read(r, binary.BigEndian, &version); err != nil {
+		return nil, fmt.Errorf("%w: reading ACO version: %v", ErrBadSwatchFile, err)
+	}
+
+	for {
+
+		if version != 1 && version != 2 {
+			return nil, fmt.Errorf("%w: unsupported ACO version %d", ErrBadSwatchFile, version)
+		}
+
+		if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+			return nil, fmt.Errorf("%w: reading ACO color count: %v", ErrBadSwatchFile, err)
+		}
+
+		swatches := make([]Swatch, 0, count)
+
+		for i := 0; i < int(count); i++ {
+			s, err := readACOColor(r, version == 2)
+			if err != nil {
+				return nil, fmt.Errorf("%w: ACO color %d: %v", ErrBadSwatchFile, i, err)
+			}
+			swatches = append(swatches, s)
+		}
+
+		if version == 2 {
+			return swatches, nil
+		}
+
+		// a version 2 section with names may follow the version 1 section
+		err := binary.Read(r, binary.BigEndian, &version)
+		if errors.Is(err, io.EOF) {
+			return swatches, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading ACO version: %v", ErrBadSwatchFile, err)
+		}
+	}
+}
+
+func readACOColor(r io.Reader, named bool) (Swatch, error) {
+
+	var s Swatch
+	var entry struct {
+		Space  uint16
+		Values [4]uint16
+	}
+
+	if err := binary.Read(r, binary.BigEndian, &entry); err != nil {
+		return s, err
+	}
+
+	if named {
+
+		var n uint32
+
+		if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+			return s, err
+		}
+
+		if n > acoMaxNameUnits {
+			return s, fmt.Errorf("name length %d too large", n)
+		}
+
+		units := make([]uint16, n)
+
+		if err := binary.Read(r, binary.BigEndian, units); err != nil {
+			return s, err
+		}
+
+		s.Name = decodeUTF16(units)
+	}
+
+	v := entry.Values
+
+	switch entry.Space {
+	case acoRGB:
+		s.Model = SwatchRGB
+		s.Values = [4]float64{float64(v[0]) / 0xffff, float64(v[1]) / 0xffff, float64(v[2]) / 0xffff}
+	case acoHSB:
+		s.Model = SwatchRGB
+		r, g, b := hsvToRGB(float64(v[0])/0xffff*360, float64(v[1])/0xffff, float64(v[2])/0xffff)
+		s.Values = [4]float64{r, g, b}
+	case acoCMYK:
+		// ink coverage is stored inverted, 0 being 100%
+		s.Model = SwatchCMYK
+		s.Values = [4]float64{1 - float64(v[0])/0xffff, 1 - float64(v[1])/0xffff, 1 - float64(v[2])/0xffff, 1 - float64(v[3])/0xffff}
+	case acoLab:
+		s.Model = SwatchLab
+		s.Values = [4]float64{float64(v[0]) / 100, float64(int16(v[1])) / 100, float64(int16(v[2])) / 100}
+	case acoGray:
+		s.Model = SwatchGray
+		s.Values = [4]float64{1 - float64(v[0])/10000}
+	default:
+		return s, fmt.Errorf("unsupported color space %d", entry.Space)
+	}
+
+	return s, nil
+}
+
+// WriteACO writes the swatches as a Photoshop .aco file containing both the version 1 and
+// named version 2 sections; groups and swatch types are not supported by the format and discarded
+func WriteACO(w io.Writer, swatches []Swatch) error {
+
+	if len(swatches) > math.MaxUint16 {
+		return fmt.Errorf("colors: too many swatches for ACO, %d", len(swatches))
+	}
+
+	entries := make([][5]uint16, len(swatches))
+
+	for i, s := range swatches {
+
+		v := s.Values
+		scale := func(f, top float64) uint16 {
+			return uint16(math.Floor(math.Max(0, math.Min(1, f))*top + .5))
+		}
+
+		switch s.Model {
+		case SwatchRGB:
+			entries[i] = [5]uint16{acoRGB, scale(v[0], 0xffff), scale(v[1], 0xffff), scale(v[2], 0xffff)}
+		case SwatchCMYK:
+			entries[i] = [5]uint16{acoCMYK, scale(1-v[0], 0xffff), scale(1-v[1], 0xffff), scale(1-v[2], 0xffff), scale(1-v[3], 0xffff)}
+		case SwatchLab:
+			entries[i] = [5]uint16{acoLab, scale(v[0]/100, 10000), uint16(int16(math.Round(v[1] * 100))), uint16(int16(math.Round(v[2] * 100)))}
+		case SwatchGray:
+			entries[i] = [5]uint16{acoGray, scale(1-v[0], 10000)}
+		default:
+			return fmt.Errorf("colors: unsupported swatch model %d", s.Model)
+		}
+	}
+
+	for _, version := range []uint16{1, 2} {
+
+		if err := binary.Write(w, binary.BigEndian, [2]uint16{version, uint16(len(swatches))}); err != nil {
+			return err
+		}
+
+		for i, e := range entries {
+
+			if err := binary.Write(w, binary.BigEndian, e); err != nil {
+				return err
+			}
+
+			if version == 1 {
+				continue
+			}
+
+			units := encodeUTF16(swatches[i].Name)
+
+			if err := binary.Write(w, binary.BigEndian, uint32(len(units))); err != nil {
+				return err
+			}
+
+			if err := binary.Write(w, binary.BigEndian, units); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
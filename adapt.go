@@ -0,0 +1,334 @@
+package colors
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrBadIlluminant is returned when parsing an unknown illuminant name
+	ErrBadIlluminant = errors.New("colors: unknown illuminant")
+
+	// ErrBadCAT is returned when adapting colors with an unknown CAT
+	ErrBadCAT = errors.New("colors: unknown chromatic adaptation transform")
+)
+
+// CAT is a chromatic adaptation transform, predicting how a color seen under one white point
+// appears under another by scaling the responses of the eye's cones
+type CAT uint8
+
+// Chromatic adaptation transforms
+const (
+	// CATBradford is the Bradford transform, used by ICC profiles and CSS
+	CATBradford CAT = iota
+
+	// CATVonKries is the von Kries transform using the Hunt-Pointer-Estevez cone responses
+	CATVonKries
+
+	// CATCAT02 is the transform of the CIECAM02 color appearance model
+	CATCAT02
+
+	// CATCAT16 is the transform of the CAM16 color appearance model
+	CATCAT16
+
+	// CATXYZScaling scales XYZ directly, the simplest and least accurate transform
+	CATXYZScaling
+)
+
+var catNames = map[CAT]string{
+	CATBradford:   "bradford",
+	CATVonKries:   "von-kries",
+	CATCAT02:      "cat02",
+	CATCAT16:      "cat16",
+	CATXYZScaling: "xyz-scaling",
+}
+
+// catCones are the matrices converting XYZ to the cone responses of each transform
+var catCones = map[CAT]matrix3{
+	CATBradford: {
+		{0.8951, 0.2664, -0.1614},
+		{-0.7502, 1.7135, 0.0367},
+		{0.0389, -0.0685, 1.0296},
+	},
+	CATVonKries: {
+		{0.40024, 0.70760, -0.08081},
+		{-0.22630, 1.16532, 0.04570},
+		{0, 0, 0.91822},
+	},
+	CATCAT02: {
+		{0.7328, 0.4296, -0.1624},
+		{-0.7036, 1.6975, 0.0061},
+		{0.0030, 0.0136, 0.9834},
+	},
+	CATCAT16: {
+		{0.401288, 0.650173, -0.051461},
+		{-0.250268, 1.204414, 0.045854},
+		{-0.002079, 0.048952, 0.953127},
+	},
+	CATXYZScaling: identity,
+}
+
+// String returns the CAT's name, e.g. bradford
+func (cat CAT) String() string {
+	return catNames[cat]
+}
+
+// WhitePoint is the XYZ tristimulus value of a white, normalized to a Y of 1
+type WhitePoint struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// WhitePointXY returns the WhitePoint of the CIE xy chromaticity coordinates
+func WhitePointXY(x, y float64) WhitePoint {
+	return WhitePoint{X: x / y, Y: 1, Z: (1 - x - y) / y}
+}
+
+// Matrix returns the matrix adapting XYZ colors seen under the white point from to the white point to,
+// or ErrBadCAT when cat is not one of the CAT constants
+func (cat CAT) Matrix(from, to WhitePoint) ([3][3]float64, error) {
+
+	cones, ok := catCones[cat]
+	if !ok {
+		return [3][3]float64{}, ErrBadCAT
+	}
+
+	src := cones.apply([3]float64{from.X, from.Y, from.Z})
+	dst := cones.apply([3]float64{to.X, to.Y, to.Z})
+
+	scale := matrix3{{dst[0] / src[0], 0, 0}, {0, dst[1] / src[1], 0}, {0, 0, dst[2] / src[2]}}
+
+	return cones.inverse().mul(scale).mul(cones), nil
+}
+
+// Adapt returns the XYZ color x, y, z seen under the white point from as it appears under the white point to,
+// or ErrBadCAT when cat is not one of the CAT constants
+func (cat CAT) Adapt(x, y, z float64, from, to WhitePoint) (float64, float64, float64, error) {
+
+	m, err := cat.Matrix(from, to)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	v := matrix3(m).apply([3]float64{x, y, z})
+
+	return v[0], v[1], v[2], nil
+}
+
+// WhitePoint returns the white point the color space's matrices are relative to, CSS Color 4's
+// D50 for ProPhoto RGB and XYZ D50 and its D65 otherwise. These are derived from xy chromaticities
+// rounded to 4 decimals, so they differ slightly from the Illuminant white points
+func (s ColorSpace) WhitePoint() WhitePoint {
+
+	w := d65White
+
+	if colorSpaces[s].d50 {
+		w = d50White
+	}
+
+	return WhitePoint{X: w[0], Y: w[1], Z: w[2]}
+}
+
+// ConvertWith converts the color to another ColorSpace as Convert does, but adapting between the
+// white points of D65 and D50 spaces with cat rather than Bradford, or returns ErrBadCAT when cat
+// is not one of the CAT constants. CATBradford returns exactly the result of Convert
+func (c *SpaceColor) ConvertWith(space ColorSpace, cat CAT) (*SpaceColor, error) {
+
+	if cat == CATBradford {
+		return c.Convert(space), nil
+	}
+
+	d65, d50 := SpaceXYZD65.WhitePoint(), SpaceXYZD50.WhitePoint()
+
+	toD50, err := cat.Matrix(d65, d50)
+	if err != nil {
+		return nil, err
+	}
+
+	toD65, _ := cat.Matrix(d50, d65)
+
+	return c.convert(space, toD50, toD65), nil
+}
+
+// Adapt returns the color, seen under its space's own white point, as it appears under the white
+// point to, adapted using cat, or ErrBadCAT when cat is not one of the CAT constants.
+// The result is in SpaceXYZD50 when to is SpaceXYZD50's white point, and otherwise holds the XYZ
+// tristimulus values under to in SpaceXYZD65
+func (c *SpaceColor) Adapt(to WhitePoint, cat CAT) (*SpaceColor, error) {
+
+	xyz := SpaceXYZD65
+
+	if colorSpaces[c.Space].d50 {
+		xyz = SpaceXYZD50
+	}
+
+	v := c.Convert(xyz)
+
+	x, y, z, err := cat.Adapt(v.R, v.G, v.B, c.Space.WhitePoint(), to)
+	if err != nil {
+		return nil, err
+	}
+
+	space := SpaceXYZD65
+
+	if to == SpaceXYZD50.WhitePoint() {
+		space = SpaceXYZD50
+	}
+
+	return &SpaceColor{Space: space, R: x, G: y, B: z, A: c.A}, nil
+}
+
+// mul returns the matrix product m × n
+func (m matrix3) mul(n matrix3) matrix3 {
+
+	var p matrix3
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			p[i][j] = m[i][0]*n[0][j] + m[i][1]*n[1][j] + m[i][2]*n[2][j]
+		}
+	}
+
+	return p
+}
+
+// inverse returns the inverse of the matrix, which must not be singular
+func (m matrix3) inverse() matrix3 {
+
+	a, b, c := m[0][0], m[0][1], m[0][2]
+	d, e, f := m[1][0], m[1][1], m[1][2]
+	g, h, i := m[2][0], m[2][1], m[2][2]
+
+	det := a*(e*i-f*h) - b*(d*i-f*g) + c*(d*h-e*g)
+
+	return matrix3{
+		{(e*i - f*h) / det, (c*h - b*i) / det, (b*f - c*e) / det},
+		{(f*g - d*i) / det, (a*i - c*g) / det, (c*d - a*f) / det},
+		{(d*h - e*g) / det, (b*g - a*h) / det, (a*e - b*d) / det},
+	}
+}
+
+// Observer is a CIE standard colorimetric observer
+type Observer uint8
+
+// Standard observers
+const (
+	// Observer2 is the CIE 1931 2° standard observer
+	Observer2 Observer = iota
+
+	// Observer10 is the CIE 1964 10° supplementary standard observer
+	Observer10
+)
+
+// Illuminant is a CIE standard illuminant
+type Illuminant uint8
+
+// Standard illuminants
+const (
+	// IlluminantA is incandescent tungsten light
+	IlluminantA Illuminant = iota
+
+	// IlluminantB is obsolete direct noon sunlight
+	IlluminantB
+
+	// IlluminantC is obsolete average daylight
+	IlluminantC
+
+	// IlluminantD50 is horizon daylight, the white point of print and ICC profiles
+	IlluminantD50
+
+	// IlluminantD55 is mid-morning or mid-afternoon daylight
+	IlluminantD55
+
+	// IlluminantD65 is noon daylight, the white point of sRGB and most displays
+	IlluminantD65
+
+	// IlluminantD75 is north sky daylight
+	IlluminantD75
+
+	// IlluminantD93 is the bluish white of older CRT displays
+	IlluminantD93
+
+	// IlluminantE is the equal energy illuminant
+	IlluminantE
+
+	// IlluminantF1 to IlluminantF6 are standard fluorescent lamps
+	IlluminantF1
+	IlluminantF2
+	IlluminantF3
+	IlluminantF4
+	IlluminantF5
+	IlluminantF6
+
+	// IlluminantF7 to IlluminantF9 are broadband fluorescent lamps
+	IlluminantF7
+	IlluminantF8
+	IlluminantF9
+
+	// IlluminantF10 to IlluminantF12 are narrow tri-band fluorescent lamps
+	IlluminantF10
+	IlluminantF11
+	IlluminantF12
+)
+
+// illuminant is the name and white point chromaticities of an Illuminant for the 2° and 10° observers
+type illuminant struct {
+	name string
+	xy   [2][2]float64
+}
+
+// illuminants are the CIE standard illuminants
+// https://en.wikipedia.org/wiki/Standard_illuminant#White_points_of_standard_illuminants
+var illuminants = map[Illuminant]illuminant{
+	IlluminantA:   {"A", [2][2]float64{{0.44757, 0.40745}, {0.45117, 0.40594}}},
+	IlluminantB:   {"B", [2][2]float64{{0.34842, 0.35161}, {0.34980, 0.35270}}},
+	IlluminantC:   {"C", [2][2]float64{{0.31006, 0.31616}, {0.31039, 0.31905}}},
+	IlluminantD50: {"D50", [2][2]float64{{0.34567, 0.35850}, {0.34773, 0.35952}}},
+	IlluminantD55: {"D55", [2][2]float64{{0.33242, 0.34743}, {0.33411, 0.34877}}},
+	IlluminantD65: {"D65", [2][2]float64{{0.31271, 0.32902}, {0.31382, 0.33100}}},
+	IlluminantD75: {"D75", [2][2]float64{{0.29902, 0.31485}, {0.29968, 0.31740}}},
+	IlluminantD93: {"D93", [2][2]float64{{0.28315, 0.29711}, {0.28327, 0.30043}}},
+	IlluminantE:   {"E", [2][2]float64{{1.0 / 3, 1.0 / 3}, {1.0 / 3, 1.0 / 3}}},
+	IlluminantF1:  {"F1", [2][2]float64{{0.31310, 0.33727}, {0.31811, 0.33559}}},
+	IlluminantF2:  {"F2", [2][2]float64{{0.37208, 0.37529}, {0.37925, 0.36733}}},
+	IlluminantF3:  {"F3", [2][2]float64{{0.40910, 0.39430}, {0.41761, 0.38324}}},
+	IlluminantF4:  {"F4", [2][2]float64{{0.44018, 0.40329}, {0.44920, 0.39074}}},
+	IlluminantF5:  {"F5", [2][2]float64{{0.31379, 0.34531}, {0.31975, 0.34246}}},
+	IlluminantF6:  {"F6", [2][2]float64{{0.37790, 0.38835}, {0.38660, 0.37847}}},
+	IlluminantF7:  {"F7", [2][2]float64{{0.31292, 0.32933}, {0.31569, 0.32960}}},
+	IlluminantF8:  {"F8", [2][2]float64{{0.34588, 0.35875}, {0.34902, 0.35939}}},
+	IlluminantF9:  {"F9", [2][2]float64{{0.37417, 0.37281}, {0.37829, 0.37045}}},
+	IlluminantF10: {"F10", [2][2]float64{{0.34609, 0.35986}, {0.35090, 0.35444}}},
+	IlluminantF11: {"F11", [2][2]float64{{0.38052, 0.37713}, {0.38541, 0.37123}}},
+	IlluminantF12: {"F12", [2][2]float64{{0.43695, 0.40441}, {0.44256, 0.39717}}},
+}
+
+// String returns the Illuminant's name, e.g. D65
+func (i Illuminant) String() string {
+	return illuminants[i].name
+}
+
+// ParseIlluminant parses an Illuminant's name as returned by String, ignoring case, or returns ErrBadIlluminant
+func ParseIlluminant(name string) (Illuminant, error) {
+
+	for i, ill := range illuminants {
+		if strings.EqualFold(ill.name, name) {
+			return i, nil
+		}
+	}
+
+	return 0, ErrBadIlluminant
+}
+
+// WhitePoint returns the white point of the Illuminant for the observer
+func (i Illuminant) WhitePoint(o Observer) WhitePoint {
+
+	xy := illuminants[i].xy[0]
+
+	if o == Observer10 {
+		xy = illuminants[i].xy[1]
+	}
+
+	return WhitePointXY(xy[0], xy[1])
+}
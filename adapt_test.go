@@ -0,0 +1,146 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func nearWhitePoint(w WhitePoint, x, y, z float64) bool {
+	return math.Abs(w.X-x) < 1e-4 && math.Abs(w.Y-y) < 1e-4 && math.Abs(w.Z-z) < 1e-4
+}
+
+func TestIlluminants(t *testing.T) {
+
+	Equal(t, nearWhitePoint(IlluminantD65.WhitePoint(Observer2), 0.95047, 1, 1.08883), true)
+	Equal(t, nearWhitePoint(IlluminantD50.WhitePoint(Observer2), 0.96422, 1, 0.82521), true)
+	Equal(t, nearWhitePoint(IlluminantA.WhitePoint(Observer2), 1.09850, 1, 0.35585), true)
+	Equal(t, nearWhitePoint(IlluminantD65.WhitePoint(Observer10), 0.94811, 1, 1.07304), true)
+	Equal(t, nearWhitePoint(IlluminantE.WhitePoint(Observer10), 1, 1, 1), true)
+
+	for i := IlluminantA; i <= IlluminantF12; i++ {
+
+		Equal(t, i.String() != "", true)
+
+		parsed, err := ParseIlluminant(i.String())
+		Equal(t, err, nil)
+		Equal(t, parsed, i)
+	}
+
+	i, err := ParseIlluminant("f11")
+	Equal(t, err, nil)
+	Equal(t, i, IlluminantF11)
+
+	_, err = ParseIlluminant("D66")
+	Equal(t, err, ErrBadIlluminant)
+}
+
+func TestChromaticAdaptation(t *testing.T) {
+
+	d65 := IlluminantD65.WhitePoint(Observer2)
+	a := IlluminantA.WhitePoint(Observer2)
+
+	for cat := CATBradford; cat <= CATXYZScaling; cat++ {
+
+		Equal(t, cat.String() != "", true)
+
+		// the white point maps to the white point
+		x, y, z, err := cat.Adapt(d65.X, d65.Y, d65.Z, d65, a)
+		Equal(t, err, nil)
+		Equal(t, nearWhitePoint(WhitePoint{X: x, Y: y, Z: z}, a.X, a.Y, a.Z), true)
+
+		// adapting there and back is lossless
+		x, y, z, _ = cat.Adapt(.3, .2, .1, d65, a)
+		x, y, z, _ = cat.Adapt(x, y, z, a, d65)
+		Equal(t, nearWhitePoint(WhitePoint{X: x, Y: y, Z: z}, .3, .2, .1), true)
+	}
+
+	// the Bradford transform between the CSS white points is the one used converting between spaces
+	m, err := CATBradford.Matrix(WhitePointXY(0.3127, 0.3290), WhitePointXY(0.3457, 0.3585))
+	Equal(t, err, nil)
+
+	for i := range m {
+		for j := range m[i] {
+			Equal(t, math.Abs(m[i][j]-d65ToD50[i][j]) < 1e-4, true)
+		}
+	}
+
+	// transforms differ away from the white point
+	bx, _, _, _ := CATBradford.Adapt(.3, .2, .1, d65, a)
+	sx, _, _, _ := CATXYZScaling.Adapt(.3, .2, .1, d65, a)
+	NotEqual(t, bx, sx)
+
+	// unknown transforms are rejected rather than replaced
+	_, err = CAT(100).Matrix(d65, a)
+	Equal(t, err, ErrBadCAT)
+
+	_, _, _, err = CAT(100).Adapt(.3, .2, .1, d65, a)
+	Equal(t, err, ErrBadCAT)
+}
+
+func TestSpaceColorAdapt(t *testing.T) {
+
+	d65 := SpaceXYZD65.WhitePoint()
+	d50 := SpaceXYZD50.WhitePoint()
+	a := IlluminantA.WhitePoint(Observer2)
+
+	Equal(t, SpaceSRGB.WhitePoint(), d65)
+	Equal(t, SpaceProPhotoRGB.WhitePoint(), d50)
+	Equal(t, nearWhitePoint(d65, 0.95046, 1, 1.08906), true)
+	Equal(t, nearWhitePoint(d50, 0.96430, 1, 0.82510), true)
+
+	// the spaces' white points are those their matrices map white to
+	white := (&SpaceColor{Space: SpaceSRGB, R: 1, G: 1, B: 1, A: 1}).Convert(SpaceXYZD65)
+	Equal(t, math.Abs(white.R-d65.X) < 1e-12 && math.Abs(white.G-d65.Y) < 1e-12 && math.Abs(white.B-d65.Z) < 1e-12, true)
+
+	// a space's white is adapted from its own white point
+	adapted, err := (&SpaceColor{Space: SpaceXYZD65, R: d65.X, G: d65.Y, B: d65.Z, A: .5}).Adapt(a, CATBradford)
+	Equal(t, err, nil)
+	Equal(t, adapted.Space, SpaceXYZD65)
+	Equal(t, adapted.A, .5)
+	Equal(t, nearWhitePoint(WhitePoint{X: adapted.R, Y: adapted.G, Z: adapted.B}, a.X, a.Y, a.Z), true)
+
+	adapted, err = (&SpaceColor{Space: SpaceProPhotoRGB, R: 1, G: 1, B: 1, A: 1}).Adapt(d65, CATCAT16)
+	Equal(t, err, nil)
+	Equal(t, math.Abs(adapted.R-d65.X) < 1e-9 && math.Abs(adapted.G-d65.Y) < 1e-9 && math.Abs(adapted.B-d65.Z) < 1e-9, true)
+
+	// adapting to D50 can be chained with Convert
+	adapted, err = (&SpaceColor{Space: SpaceSRGB, R: 1, G: 1, B: 1, A: 1}).Adapt(d50, CATCAT02)
+	Equal(t, err, nil)
+	Equal(t, adapted.Space, SpaceXYZD50)
+
+	prophoto := adapted.Convert(SpaceProPhotoRGB)
+	Equal(t, math.Abs(prophoto.R-1) < 1e-9 && math.Abs(prophoto.G-1) < 1e-9 && math.Abs(prophoto.B-1) < 1e-9, true)
+
+	_, err = (&SpaceColor{Space: SpaceSRGB, A: 1}).Adapt(a, CAT(100))
+	Equal(t, err, ErrBadCAT)
+}
+
+func TestSpaceColorConvertWith(t *testing.T) {
+
+	orange := &SpaceColor{Space: SpaceSRGB, R: 1, G: .5, B: .1, A: .5}
+
+	for cat := CATBradford; cat <= CATXYZScaling; cat++ {
+
+		converted, err := orange.ConvertWith(SpaceXYZD50, cat)
+		Equal(t, err, nil)
+		Equal(t, converted.Space, SpaceXYZD50)
+		Equal(t, converted.A, .5)
+
+		back, err := converted.ConvertWith(SpaceSRGB, cat)
+		Equal(t, err, nil)
+		Equal(t, math.Abs(back.R-1) < 1e-9 && math.Abs(back.G-.5) < 1e-9 && math.Abs(back.B-.1) < 1e-9, true)
+	}
+
+	bradford, _ := orange.ConvertWith(SpaceXYZD50, CATBradford)
+	Equal(t, bradford, orange.Convert(SpaceXYZD50))
+
+	// transforms only differ when the white point changes
+	cat02, _ := orange.ConvertWith(SpaceXYZD50, CATCAT02)
+	NotEqual(t, cat02.B, bradford.B)
+
+	p3, _ := orange.ConvertWith(SpaceDisplayP3, CATCAT02)
+	Equal(t, p3, orange.Convert(SpaceDisplayP3))
+
+	_, err := orange.ConvertWith(SpaceXYZD50, CAT(100))
+	Equal(t, err, ErrBadCAT)
+}
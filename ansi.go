@@ -0,0 +1,89 @@
+package colors
+
+import "strconv"
+
+// ANSIReset is the escape sequence that resets all terminal colors and styles
+const ANSIReset = "\x1b[0m"
+
+// ANSIMode is the color support level used when generating ANSI escape sequences
+type ANSIMode uint8
+
+// ANSI modes
+const (
+	// ANSINone outputs no escape sequences
+	ANSINone ANSIMode = iota
+
+	// ANSI16 uses the 8 basic and 8 bright terminal colors
+	ANSI16
+
+	// ANSI256 uses the xterm 256 color palette
+	ANSI256
+
+	// ANSITrueColor uses 24-bit RGB colors
+	ANSITrueColor
+)
+
+// ansiSequence returns the escape sequence for c, base being 30 for foreground and 40 for background
+func ansiSequence(c Color, mode ANSIMode, base int) string {
+
+	if c == nil || mode == ANSINone {
+		return ""
+	}
+
+	return "\x1b[" + ansiParams(c.ToRGB(), mode, base) + "m"
+}
+
+// ansiParams returns the SGR parameters selecting c in the given mode, base being 30 for foreground
+// and 40 for background; mode must not be ANSINone
+func ansiParams(c *RGBColor, mode ANSIMode, base int) string {
+
+	switch mode {
+	case ANSITrueColor:
+		return strconv.Itoa(base+8) + ";2;" + strconv.Itoa(int(c.R)) + ";" +
+			strconv.Itoa(int(c.G)) + ";" + strconv.Itoa(int(c.B))
+	case ANSI256:
+		// the first 16 entries are skipped as terminals commonly redefine them
+		return strconv.Itoa(base+8) + ";5;" + strconv.Itoa(int(nearestXTerm(c, 16, 255, DistanceOKLab)))
+	default:
+		return ansiBasicParam(int(nearestXTerm(c, 0, 15, DistanceOKLab)), base)
+	}
+}
+
+// ansiBasicParam returns the SGR parameter selecting the basic color at index i in [0, 16)
+func ansiBasicParam(i, base int) string {
+
+	if i >= 8 {
+		return strconv.Itoa(base + 60 + i - 8)
+	}
+
+	return strconv.Itoa(base + i)
+}
+
+// ANSIForeground returns the escape sequence setting the terminal foreground to c,
+// downsampled to the perceptually nearest color supported by mode
+func ANSIForeground(c Color, mode ANSIMode) string {
+	return ansiSequence(c, mode, 30)
+}
+
+// ANSIBackground returns the escape sequence setting the terminal background to c,
+// downsampled to the perceptually nearest color supported by mode
+func ANSIBackground(c Color, mode ANSIMode) string {
+	return ansiSequence(c, mode, 40)
+}
+
+// Colorize wraps text in 24-bit foreground and background escape sequences followed by a reset,
+// either color may be nil to leave it unchanged
+func Colorize(text string, fg, bg Color) string {
+	return ColorizeMode(text, fg, bg, ANSITrueColor)
+}
+
+// ColorizeMode wraps text in foreground and background escape sequences for the given mode
+// followed by a reset, either color may be nil to leave it unchanged
+func ColorizeMode(text string, fg, bg Color, mode ANSIMode) string {
+
+	if mode == ANSINone || (fg == nil && bg == nil) {
+		return text
+	}
+
+	return ANSIForeground(fg, mode) + ANSIBackground(bg, mode) + text + ANSIReset
+}
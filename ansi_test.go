@@ -0,0 +1,29 @@
+package colors
+
+import "testing"
+
+func TestANSI(t *testing.T) {
+
+	orange, _ := ParseHEX("#ff8700")
+	near, _ := RGB(250, 130, 10)
+	black, _ := RGB(0, 0, 0)
+	gray, _ := RGB(128, 128, 128)
+
+	Equal(t, ANSIForeground(orange, ANSITrueColor), "\x1b[38;2;255;135;0m")
+	Equal(t, ANSIBackground(orange, ANSITrueColor), "\x1b[48;2;255;135;0m")
+	Equal(t, ANSIForeground(orange, ANSI256), "\x1b[38;5;208m")
+	Equal(t, ANSIForeground(near, ANSI256), "\x1b[38;5;208m")
+	Equal(t, ANSIBackground(black, ANSI256), "\x1b[48;5;16m")
+	Equal(t, ANSIForeground(gray, ANSI256), "\x1b[38;5;244m")
+	Equal(t, ANSIForeground(black, ANSI16), "\x1b[30m")
+	Equal(t, ANSIBackground(gray, ANSI16), "\x1b[100m")
+	Equal(t, ANSIForeground(orange, ANSI16), "\x1b[91m")
+	Equal(t, ANSIForeground(orange, ANSINone), "")
+	Equal(t, ANSIForeground(nil, ANSITrueColor), "")
+
+	Equal(t, Colorize("hi", orange, nil), "\x1b[38;2;255;135;0mhi\x1b[0m")
+	Equal(t, Colorize("hi", nil, black), "\x1b[48;2;0;0;0mhi\x1b[0m")
+	Equal(t, Colorize("hi", nil, nil), "hi")
+	Equal(t, ColorizeMode("hi", orange, black, ANSI256), "\x1b[38;5;208m\x1b[48;5;16mhi\x1b[0m")
+	Equal(t, ColorizeMode("hi", orange, black, ANSINone), "hi")
+}
@@ -0,0 +1,276 @@
+package colors
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	aseSignature     = "ASEF"
+	aseGroupStart    = 0xc001
+	aseGroupEnd      = 0xc002
+	aseColorEntry    = 0x0001
+	aseMaxBlockBytes = 1 << 20
+)
+
+var (
+	aseModels = map[SwatchModel]string{
+		SwatchRGB:  "RGB ",
+		SwatchCMYK: "CMYK",
+		SwatchLab:  "LAB ",
+		SwatchGray: "Gray",
+	}
+	aseChannels = map[SwatchModel]int{
+		SwatchRGB:  3,
+		SwatchCMYK: 4,
+		SwatchLab:  3,
+		SwatchGray: 1,
+	}
+	aseTypes = map[uint16]SwatchType{
+		0: SwatchGlobal,
+		1: SwatchSpot,
+		2: SwatchProcess,
+	}
+)
+
+// ReadASE reads an Adobe Swatch Exchange .ase file, group membership being recorded in each Swatch's Group
+func ReadASE(r io.Reader) ([]Swatch, error) {
+
+	var header struct {
+		Signature [4]byte
+		Major     uint16
+		Minor     uint16
+		Blocks    uint32
+	}
+
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, fmt.Errorf("%w: reading ASE header: %v", ErrBadSwatchFile, err)
+	}
+
+	if string(header.Signature[:]) != aseSignature {
+		return nil, fmt.Errorf("%w: missing ASEF signature", ErrBadSwatchFile)
+	}
+
+	var swatches []Swatch
+	var group string
+
+	for i := uint32(0); i < header.Blocks; i++ {
+
+		var block struct {
+			Type   uint16
+			Length uint32
+		}
+
+		if err := binary.Read(r, binary.BigEndian, &block); err != nil {
+			return nil, fmt.Errorf("%w: reading ASE block %d: %v", ErrBadSwatchFile, i, err)
+		}
+
+		if block.Length > aseMaxBlockBytes {
+			return nil, fmt.Errorf("%w: ASE block %d length %d too large", ErrBadSwatchFile, i, block.Length)
+		}
+
+		data := make([]byte, block.Length)
+
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("%w: reading ASE block %d: %v", ErrBadSwatchFile, i, err)
+		}
+
+		switch block.Type {
+		case aseGroupStart:
+			name, _, err := readASEName(data)
+			if err != nil {
+				return nil, fmt.Errorf("%w: ASE block %d: %v", ErrBadSwatchFile, i, err)
+			}
+			group = name
+
+		case aseGroupEnd:
+			group = ""
+
+		case aseColorEntry:
+			s, err := readASEColor(data)
+			if err != nil {
+				return nil, fmt.Errorf("%w: ASE block %d: %v", ErrBadSwatchFile, i, err)
+			}
+			s.Group = group
+			swatches = append(swatches, s)
+		}
+	}
+
+	return swatches, nil
+}
+
+// readASEName reads a length prefixed, null terminated UTF-16 name returning the remaining data
+func readASEName(data []byte) (string, []byte, error) {
+
+	if len(data) < 2 {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+
+	n := int(binary.BigEndian.Uint16(data)) * 2
+	data = data[2:]
+
+	if len(data) < n {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+
+	units := make([]uint16, n/2)
+
+	for i := range units {
+		units[i] = binary.BigEndian.Uint16(data[i*2:])
+	}
+
+	return decodeUTF16(units), data[n:], nil
+}
+
+func readASEColor(data []byte) (Swatch, error) {
+
+	var s Swatch
+
+	name, data, err := readASEName(data)
+	if err != nil {
+		return s, err
+	}
+
+	s.Name = name
+
+	if len(data) < 4 {
+		return s, io.ErrUnexpectedEOF
+	}
+
+	model := string(data[:4])
+	data = data[4:]
+	found := false
+
+	for m, code := range aseModels {
+		if code == model {
+			s.Model = m
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return s, fmt.Errorf("unsupported color model %q", model)
+	}
+
+	channels := aseChannels[s.Model]
+
+	if len(data) < channels*4+2 {
+		return s, io.ErrUnexpectedEOF
+	}
+
+	for i := 0; i < channels; i++ {
+		s.Values[i] = float64(math.Float32frombits(binary.BigEndian.Uint32(data[i*4:])))
+	}
+
+	// Lab lightness is stored as a fraction
+	if s.Model == SwatchLab {
+		s.Values[0] *= 100
+	}
+
+	typ, ok := aseTypes[binary.BigEndian.Uint16(data[channels*4:])]
+	if !ok {
+		return s, fmt.Errorf("unsupported color type %d", binary.BigEndian.Uint16(data[channels*4:]))
+	}
+
+	s.Type = typ
+
+	return s, nil
+}
+
+// WriteASE writes the swatches as an Adobe Swatch Exchange .ase file,
+// consecutive swatches of the same Group being written as a group
+func WriteASE(w io.Writer, swatches []Swatch) error {
+
+	var body bytes.Buffer
+	var blocks uint32
+	var group string
+
+	writeBlock := func(typ uint16, data []byte) {
+		_ = binary.Write(&body, binary.BigEndian, typ)
+		_ = binary.Write(&body, binary.BigEndian, uint32(len(data)))
+		body.Write(data)
+		blocks++
+	}
+
+	for _, s := range swatches {
+
+		if s.Group != group {
+
+			if group != "" {
+				writeBlock(aseGroupEnd, nil)
+			}
+
+			if s.Group != "" {
+				writeBlock(aseGroupStart, aseName(s.Group))
+			}
+
+			group = s.Group
+		}
+
+		model, ok := aseModels[s.Model]
+		if !ok {
+			return fmt.Errorf("colors: unsupported swatch model %d", s.Model)
+		}
+
+		data := bytes.NewBuffer(aseName(s.Name))
+		data.WriteString(model)
+
+		for i := 0; i < aseChannels[s.Model]; i++ {
+
+			v := s.Values[i]
+
+			if s.Model == SwatchLab && i == 0 {
+				v /= 100
+			}
+
+			_ = binary.Write(data, binary.BigEndian, float32(v))
+		}
+
+		var typ uint16 = 2
+
+		for code, t := range aseTypes {
+			if t == s.Type {
+				typ = code
+			}
+		}
+
+		_ = binary.Write(data, binary.BigEndian, typ)
+
+		writeBlock(aseColorEntry, data.Bytes())
+	}
+
+	if group != "" {
+		writeBlock(aseGroupEnd, nil)
+	}
+
+	var header bytes.Buffer
+	header.WriteString(aseSignature)
+	_ = binary.Write(&header, binary.BigEndian, [2]uint16{1, 0})
+	_ = binary.Write(&header, binary.BigEndian, blocks)
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+
+	_, err := w.Write(body.Bytes())
+
+	return err
+}
+
+// aseName returns the length prefixed, null terminated UTF-16 encoding of name
+func aseName(name string) []byte {
+
+	units := encodeUTF16(name)
+	b := make([]byte, 2+len(units)*2)
+	binary.BigEndian.PutUint16(b, uint16(len(units)))
+
+	for i, u := range units {
+		binary.BigEndian.PutUint16(b[2+i*2:], u)
+	}
+
+	return b
+}
@@ -0,0 +1,415 @@
+package colors
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Default OKLCH bounds of categorical palettes, avoiding colors too dark, light or gray to tell apart
+const (
+	defaultCategoricalMinLightness = .45
+	defaultCategoricalMaxLightness = .85
+	defaultCategoricalMinChroma    = .08
+	defaultCategoricalMaxChroma    = .25
+)
+
+const (
+	// categoricalCandidates is the minimum number of colors sampled within the constraints
+	// that palettes are chosen from
+	categoricalCandidates = 4000
+
+	// categoricalRefinements is the number of passes spent improving a palette after its
+	// colors have been picked
+	categoricalRefinements = 4
+)
+
+// colorblindSimulations are the Machado, Oliveira and Fernandes (2009) matrices simulating
+// protanopia, deuteranopia and tritanopia in linear sRGB
+var colorblindSimulations = [][3][3]float64{
+	{
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	{
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	{
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// HueRange is a range of OKLCH hues in degrees, wrapping through 0 when From is greater than To,
+// e.g. {From: 330, To: 30} for reds
+type HueRange struct {
+	From, To float64
+}
+
+// width returns the number of degrees the range covers
+func (r HueRange) width() float64 {
+
+	w := math.Mod(r.To-r.From, 360)
+
+	if w < 0 {
+		w += 360
+	}
+
+	if w == 0 && r.From != r.To {
+		return 360
+	}
+
+	return w
+}
+
+// contains reports whether the hue in degrees [0, 360) lies within the range
+func (r HueRange) contains(h float64) bool {
+
+	from := math.Mod(r.From, 360)
+	if from < 0 {
+		from += 360
+	}
+
+	d := h - from
+	if d < 0 {
+		d += 360
+	}
+
+	return d <= r.width()
+}
+
+// hueRanges are the ranges hues are chosen from, none allowing every hue
+type hueRanges []HueRange
+
+// at returns the hue t of the way through the ranges laid end to end, t being in [0, 1)
+// so that a uniform t picks a hue uniformly across the ranges
+func (hr hueRanges) at(t float64) float64 {
+
+	if len(hr) == 0 {
+		return t * 360
+	}
+
+	var total float64
+	for _, r := range hr {
+		total += r.width()
+	}
+
+	h := t * total
+
+	for _, r := range hr {
+
+		if w := r.width(); h > w {
+			h -= w
+			continue
+		}
+
+		h = math.Mod(r.From+h, 360)
+		break
+	}
+
+	if h < 0 {
+		h += 360
+	}
+
+	return h
+}
+
+// contains reports whether the hue in degrees [0, 360) lies within any of the ranges
+func (hr hueRanges) contains(h float64) bool {
+
+	if len(hr) == 0 {
+		return true
+	}
+
+	for _, r := range hr {
+		if r.contains(h) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CategoricalOptions contains the constraints of a categorical palette
+type CategoricalOptions struct {
+	// Hues are the ranges of OKLCH hues colors are chosen from, nil allowing every hue
+	Hues []HueRange
+
+	// MinLightness and MaxLightness bound the OKLCH lightness in [0, 1], both 0 using .45 to .85
+	MinLightness, MaxLightness float64
+
+	// MinChroma and MaxChroma bound the OKLCH chroma, both 0 using .08 to .25
+	MinChroma, MaxChroma float64
+
+	// Avoid are colors the palette must also be distinct from, e.g. the background and text colors
+	Avoid []Color
+
+	// ColorblindSafe maximizes the distance between colors as seen with protanopia, deuteranopia
+	// and tritanopia as well as with normal vision
+	ColorblindSafe bool
+
+	// Seed seeds the sampling of colors, the same options and seed always returning the same palette
+	Seed int64
+}
+
+// categoricalColor is a candidate color and its OKLab coordinates under each simulated vision
+type categoricalColor struct {
+	rgb    *RGBColor
+	points []oklab
+}
+
+// distance returns the deltaEOK between the colors under the vision where they are closest
+func (c categoricalColor) distance(d categoricalColor) float64 {
+
+	dist := math.Inf(1)
+
+	for i := range c.points {
+		dist = math.Min(dist, c.points[i].distance(d.points[i]))
+	}
+
+	return dist
+}
+
+// Categorical returns n colors for categorical data, e.g. chart series, chosen within the
+// constraints of opts so that the smallest deltaEOK between any two of them, and between them
+// and the colors to avoid, is as large as possible. Fewer than n colors are returned when the
+// constraints leave fewer distinct colors, every remaining candidate duplicating a color already
+// picked or avoided, and nil when n < 1 or no color within sRGB meets the constraints
+func Categorical(n int, opts CategoricalOptions) []Color {
+
+	if n < 1 {
+		return nil
+	}
+
+	candidates := opts.candidates(n)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	avoid := make([]categoricalColor, len(opts.Avoid))
+	for i, c := range opts.Avoid {
+		avoid[i] = opts.newCategoricalColor(c.ToRGB())
+	}
+
+	// nearest is the distance from each candidate to the closest color picked or avoided
+	nearest := make([]float64, len(candidates))
+
+	for i, c := range candidates {
+
+		nearest[i] = math.Inf(1)
+
+		for _, a := range avoid {
+			nearest[i] = math.Min(nearest[i], c.distance(a))
+		}
+	}
+
+	picked := make([]int, 0, n)
+
+	// greedily pick the candidate farthest from every color picked so far, starting with the
+	// most chromatic when there is nothing to avoid
+	for len(picked) < n {
+
+		best := -1
+
+		for i, c := range candidates {
+
+			switch {
+			case nearest[i] == 0:
+			case best == -1:
+				best = i
+			case len(picked) == 0 && len(avoid) == 0:
+				if chroma(c) > chroma(candidates[best]) {
+					best = i
+				}
+			case nearest[i] > nearest[best]:
+				best = i
+			}
+		}
+
+		// every candidate duplicates a picked color
+		if best == -1 {
+			break
+		}
+
+		picked = append(picked, best)
+
+		for i, c := range candidates {
+			nearest[i] = math.Min(nearest[i], c.distance(candidates[best]))
+		}
+	}
+
+	opts.refine(candidates, avoid, picked)
+
+	colors := make([]Color, len(picked))
+	for i, p := range picked {
+		colors[i] = candidates[p].rgb
+	}
+
+	return colors
+}
+
+// refine improves a greedily picked palette by moving each color in turn to the candidate
+// farthest from every other color, whenever that is farther than the color already is.
+// The two closest colors to each candidate are kept up to date as colors move, so that a
+// pass measures about as many distances as there are picked colors times candidates
+func (opts CategoricalOptions) refine(candidates, avoid []categoricalColor, picked []int) {
+
+	if len(picked) < 2 {
+		return
+	}
+
+	// closest are the distances to the two closest colors to a candidate and their index
+	// in picked, -1 for avoided colors
+	type closest struct {
+		d1, d2 float64
+		i1, i2 int
+	}
+
+	add := func(c *closest, i int, d float64) {
+
+		switch {
+		case d < c.d1:
+			c.d2, c.i2 = c.d1, c.i1
+			c.d1, c.i1 = d, i
+		case d < c.d2:
+			c.d2, c.i2 = d, i
+		}
+	}
+
+	measure := func(c *closest, candidate categoricalColor) {
+
+		*c = closest{d1: math.Inf(1), d2: math.Inf(1), i1: -1, i2: -1}
+
+		for _, a := range avoid {
+			add(c, -1, candidate.distance(a))
+		}
+
+		for i, p := range picked {
+			add(c, i, candidate.distance(candidates[p]))
+		}
+	}
+
+	near := make([]closest, len(candidates))
+	for j, c := range candidates {
+		measure(&near[j], c)
+	}
+
+	// separation returns the distance from candidate j to the closest color other than picked[i]
+	separation := func(j, i int) float64 {
+
+		if near[j].i1 == i {
+			return near[j].d2
+		}
+
+		return near[j].d1
+	}
+
+	for pass := 0; pass < categoricalRefinements; pass++ {
+
+		moved := false
+
+		for i := range picked {
+
+			best, bestDist := picked[i], separation(picked[i], i)
+
+			for j := range candidates {
+				if d := separation(j, i); d > bestDist {
+					best, bestDist = j, d
+				}
+			}
+
+			if best == picked[i] {
+				continue
+			}
+
+			picked[i] = best
+			moved = true
+
+			// only the candidates that were closest to the moved color must be measured again
+			for j, c := range candidates {
+				if near[j].i1 == i || near[j].i2 == i {
+					measure(&near[j], c)
+				} else {
+					add(&near[j], i, c.distance(candidates[best]))
+				}
+			}
+		}
+
+		if !moved {
+			return
+		}
+	}
+}
+
+// candidates samples the colors within sRGB that meet the constraints, returning none when
+// the constraints cannot be met
+func (opts CategoricalOptions) candidates(n int) []categoricalColor {
+
+	minL, maxL := opts.MinLightness, opts.MaxLightness
+	if minL == 0 && maxL == 0 {
+		minL, maxL = defaultCategoricalMinLightness, defaultCategoricalMaxLightness
+	}
+
+	minC, maxC := opts.MinChroma, opts.MaxChroma
+	if minC == 0 && maxC == 0 {
+		minC, maxC = defaultCategoricalMinChroma, defaultCategoricalMaxChroma
+	}
+
+	size := categoricalCandidates
+	if n*50 > size {
+		size = n * 50
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	candidates := make([]categoricalColor, 0, size)
+
+	// give up when the constraints leave next to nothing of sRGB
+	for attempt := 0; attempt < size*20 && len(candidates) < size; attempt++ {
+
+		h := hueRanges(opts.Hues).at(rng.Float64())
+		c := fromLCH(minL+(maxL-minL)*rng.Float64(), minC+(maxC-minC)*rng.Float64(), h)
+
+		if c.inGamut() {
+			candidates = append(candidates, opts.newCategoricalColor(c.toRGB()))
+		}
+	}
+
+	return candidates
+}
+
+// newCategoricalColor returns the candidate of rgb, simulating color vision deficiencies
+// when the palette is colorblind safe
+func (opts CategoricalOptions) newCategoricalColor(rgb *RGBColor) categoricalColor {
+
+	c := categoricalColor{rgb: rgb, points: []oklab{rgbToOKLab(rgb.R, rgb.G, rgb.B)}}
+
+	if !opts.ColorblindSafe {
+		return c
+	}
+
+	r := srgbToLinear(float64(rgb.R) / 255)
+	g := srgbToLinear(float64(rgb.G) / 255)
+	b := srgbToLinear(float64(rgb.B) / 255)
+
+	clamp := func(v float64) float64 {
+		return math.Max(0, math.Min(1, v))
+	}
+
+	for _, m := range colorblindSimulations {
+		c.points = append(c.points, linearRGBToOKLab(
+			clamp(m[0][0]*r+m[0][1]*g+m[0][2]*b),
+			clamp(m[1][0]*r+m[1][1]*g+m[1][2]*b),
+			clamp(m[2][0]*r+m[2][1]*g+m[2][2]*b),
+		))
+	}
+
+	return c
+}
+
+// chroma returns the OKLCH chroma of the candidate as seen with normal vision
+func chroma(c categoricalColor) float64 {
+	return math.Hypot(c.points[0].A, c.points[0].B)
+}
@@ -0,0 +1,100 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+// minDistance returns the smallest deltaEOK between any two of the colors
+func minDistance(colors []Color) float64 {
+
+	dist := math.Inf(1)
+
+	for i := range colors {
+		for j := 0; j < i; j++ {
+			dist = math.Min(dist, DistanceOKLab.Distance(colors[i], colors[j]))
+		}
+	}
+
+	return dist
+}
+
+func TestCategorical(t *testing.T) {
+
+	palette := Categorical(10, CategoricalOptions{})
+	Equal(t, len(palette), 10)
+	Equal(t, minDistance(palette) > .15, true)
+
+	// palettes are deterministic for a seed
+	Equal(t, Categorical(10, CategoricalOptions{}), palette)
+	NotEqual(t, Categorical(10, CategoricalOptions{Seed: 1}), palette)
+
+	for _, c := range palette {
+		rgb := c.ToRGB()
+		l, ch, _ := rgbToOKLab(rgb.R, rgb.G, rgb.B).lch()
+		Equal(t, l > .44 && l < .86, true)
+		Equal(t, ch > .07 && ch < .26, true)
+	}
+
+	Equal(t, len(Categorical(0, CategoricalOptions{})), 0)
+	Equal(t, len(Categorical(3, CategoricalOptions{MinLightness: .99, MaxLightness: 1, MinChroma: .3, MaxChroma: .4})), 0)
+
+	// near grays leave only a handful of distinct sRGB colors
+	grays := Categorical(20, CategoricalOptions{MinLightness: .5, MaxLightness: .5, MinChroma: .001, MaxChroma: .002})
+	Equal(t, len(grays) > 0 && len(grays) < 20, true)
+	Equal(t, minDistance(grays) > 0, true)
+}
+
+func TestCategoricalConstraints(t *testing.T) {
+
+	reds := Categorical(4, CategoricalOptions{Hues: []HueRange{{From: 330, To: 30}}})
+	Equal(t, len(reds), 4)
+
+	for _, c := range reds {
+		rgb := c.ToRGB()
+		_, _, h := rgbToOKLab(rgb.R, rgb.G, rgb.B).lch()
+		Equal(t, h > 329 || h < 31, true)
+	}
+
+	white, _ := ParseHEX("#fff")
+	black, _ := ParseHEX("#000")
+
+	avoided := Categorical(6, CategoricalOptions{Avoid: []Color{white, black}, MinLightness: .1, MaxLightness: .99})
+	Equal(t, len(avoided), 6)
+
+	for _, c := range avoided {
+		Equal(t, DistanceOKLab.Distance(c, white) > .2, true)
+		Equal(t, DistanceOKLab.Distance(c, black) > .2, true)
+	}
+}
+
+func TestCategoricalColorblindSafe(t *testing.T) {
+
+	opts := CategoricalOptions{ColorblindSafe: true}
+	palette := Categorical(6, opts)
+	Equal(t, len(palette), 6)
+
+	// colors stay distinct under every simulated deficiency
+	points := make([]categoricalColor, len(palette))
+	for i, c := range palette {
+		points[i] = opts.newCategoricalColor(c.ToRGB())
+	}
+
+	for i := range points {
+		for j := 0; j < i; j++ {
+			Equal(t, points[i].distance(points[j]) > .1, true)
+		}
+	}
+
+	// simulated red and green are hard to tell apart with protanopia
+	red := opts.newCategoricalColor(&RGBColor{R: 200, G: 80, B: 40})
+	green := opts.newCategoricalColor(&RGBColor{R: 110, G: 130, B: 40})
+	Equal(t, red.points[1].distance(green.points[1]) < red.points[0].distance(green.points[0])/2, true)
+}
+
+func BenchmarkCategorical(b *testing.B) {
+
+	for n := 0; n < b.N; n++ {
+		Categorical(100, CategoricalOptions{Seed: int64(n)})
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-playground/colors"
+)
+
+// contrastReport is the WCAG 2 contrast of a foreground over a background
+type contrastReport struct {
+	Foreground string `json:"foreground"`
+	Background string `json:"background"`
+	colors.ContrastLevels
+	Pass bool `json:"pass"`
+}
+
+func runContrast(e *env, args []string) int {
+
+	fs := newFlagSet(e, "contrast")
+	asJSON := fs.Bool("json", false, "write the result as a JSON object")
+	minRatio := fs.Float64("min", 0, "exit with status 1 when the ratio is below `RATIO`, e.g. 4.5 for WCAG AA")
+
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return flagError(err)
+	}
+
+	if len(args) != 2 {
+		fs.Usage()
+		return exitError
+	}
+
+	parsed, ok := parseColors(e, args)
+	if !ok {
+		return exitError
+	}
+
+	fg, bg := parsed[0], parsed[1]
+
+	report := contrastReport{
+		Foreground:     args[0],
+		Background:     args[1],
+		ContrastLevels: colors.CheckContrast(fg, bg),
+		Pass:           colors.Contrast(fg, bg) >= *minRatio,
+	}
+
+	if *asJSON {
+		if err := json.NewEncoder(e.stdout).Encode(report); err != nil {
+			fmt.Fprintf(e.stderr, "colors: %v\n", err)
+			return exitError
+		}
+	} else {
+
+		fmt.Fprintf(e.stdout, "ratio      %.2f:1\n", report.Ratio)
+		fmt.Fprintf(e.stdout, "AA         %s\n", passFail(report.AA))
+		fmt.Fprintf(e.stdout, "AA large   %s\n", passFail(report.AALarge))
+		fmt.Fprintf(e.stdout, "AAA        %s\n", passFail(report.AAA))
+		fmt.Fprintf(e.stdout, "AAA large  %s\n", passFail(report.AAALarge))
+
+		if s := e.swatch("  The quick brown fox  ", fg, bg); s != "" {
+			fmt.Fprintf(e.stdout, "sample     %s\n", s)
+		}
+	}
+
+	if !report.Pass {
+		return exitFail
+	}
+
+	return exitOK
+}
+
+func passFail(ok bool) string {
+
+	if ok {
+		return "pass"
+	}
+
+	return "fail"
+}
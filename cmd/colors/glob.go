@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// expandPatterns expands glob patterns, including ** matching any number of directories,
+// into the matching files in order. Arguments without glob characters are returned as is
+// so missing files are reported when opened
+func expandPatterns(patterns []string) ([]string, error) {
+
+	var files []string
+	seen := make(map[string]bool)
+
+	add := func(file string) {
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+
+	for _, pattern := range patterns {
+
+		if !strings.ContainsAny(pattern, "*?[") {
+			add(pattern)
+			continue
+		}
+
+		matches, err := glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %s", pattern)
+		}
+
+		for _, m := range matches {
+			add(m)
+		}
+	}
+
+	return files, nil
+}
+
+// glob returns the files matching pattern, walking the directory preceding its first glob character
+func glob(pattern string) ([]string, error) {
+
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	root := ""
+
+	for len(segments) > 1 && !strings.ContainsAny(segments[0], "*?[") {
+		root += segments[0] + "/"
+		segments = segments[1:]
+	}
+
+	for _, seg := range segments {
+		if _, err := filepath.Match(seg, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+	}
+
+	dir := filepath.FromSlash(root)
+	if dir == "" {
+		dir = "."
+	}
+
+	var matches []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		if matchSegments(segments, strings.Split(filepath.ToSlash(rel), "/")) {
+			matches = append(matches, path)
+		}
+
+		return nil
+	})
+
+	return matches, err
+}
+
+// matchSegments matches path segments against pattern segments, ** matching zero or more segments
+func matchSegments(pattern, path []string) bool {
+
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(path) == 0 {
+		return false
+	}
+
+	ok, _ := filepath.Match(pattern[0], path[0])
+
+	return ok && matchSegments(pattern[1:], path[1:])
+}
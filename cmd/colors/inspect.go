@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-playground/colors"
+)
+
+// inspection is a color in every supported notation, and the argument it was parsed from
+type inspection struct {
+	Input string `json:"input"`
+	colors.Inspection
+}
+
+func runInspect(e *env, args []string) int {
+
+	fs := newFlagSet(e, "inspect")
+	asJSON := fs.Bool("json", false, "write each color as a JSON object on its own line")
+	showSwatch := fs.Bool("swatch", true, "render a swatch when the terminal supports colors")
+
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return flagError(err)
+	}
+
+	if len(args) == 0 {
+		fs.Usage()
+		return exitError
+	}
+
+	parsed, ok := parseColors(e, args)
+	if !ok {
+		return exitError
+	}
+
+	enc := json.NewEncoder(e.stdout)
+
+	for i, c := range parsed {
+
+		in := inspection{Input: args[i], Inspection: colors.Inspect(c)}
+
+		if *asJSON {
+			if err := enc.Encode(in); err != nil {
+				fmt.Fprintf(e.stderr, "colors: %v\n", err)
+				return exitError
+			}
+			continue
+		}
+
+		if i > 0 {
+			fmt.Fprintln(e.stdout)
+		}
+
+		fmt.Fprintln(e.stdout, in.Input)
+		fmt.Fprintf(e.stdout, "  hex        %s\n", in.HEX)
+		fmt.Fprintf(e.stdout, "  rgb        %s\n", in.RGB)
+		fmt.Fprintf(e.stdout, "  rgba       %s\n", in.RGBA)
+		fmt.Fprintf(e.stdout, "  hsl        %s\n", in.HSL)
+		fmt.Fprintf(e.stdout, "  oklch      %s\n", in.OKLCH)
+		fmt.Fprintf(e.stdout, "  ansi256    %d\n", in.ANSI256)
+		fmt.Fprintf(e.stdout, "  luminance  %.4f\n", in.Luminance)
+		fmt.Fprintf(e.stdout, "  light      %t\n", in.Light)
+		fmt.Fprintf(e.stdout, "  dark       %t\n", in.Dark)
+
+		if !*showSwatch {
+			continue
+		}
+
+		if s := e.swatch("            ", nil, c.ToRGB()); s != "" {
+			fmt.Fprintf(e.stdout, "  swatch     %s\n", s)
+		}
+	}
+
+	return exitOK
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/go-playground/colors"
+)
+
+// lintIssue is a color literal that is not within the allowed distance of the palette,
+// or a warning about a literal that could not be checked
+type lintIssue struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Text    string `json:"text"`
+	Message string `json:"message"`
+
+	// Warning is true for literals that could not be parsed, e.g. valid CSS such as lab() that
+	// colors.Parse does not support, which are reported without failing the lint
+	Warning bool `json:"warning,omitempty"`
+
+	// Nearest is the nearest palette color, nil when the literal could not be parsed
+	Nearest *match `json:"nearest,omitempty"`
+}
+
+// lineIndex converts byte offsets to 1 based lines and byte columns
+type lineIndex []int
+
+func newLineIndex(src []byte) lineIndex {
+
+	starts := lineIndex{0}
+
+	for i, b := range src {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+
+	return starts
+}
+
+func (l lineIndex) position(offset int) (line, column int) {
+
+	i := sort.Search(len(l), func(i int) bool { return l[i] > offset }) - 1
+
+	return i + 1, offset - l[i] + 1
+}
+
+// lintFile returns the colors of the stylesheet at path that are not within maxDistance of the palette
+func lintFile(path string, palette []colors.NamedColor, metric colors.DistanceMetric, maxDistance float64) ([]lintIssue, error) {
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := newLineIndex(src)
+
+	var issues []lintIssue
+
+	for _, found := range colors.ScanCSS(src) {
+
+		issue := lintIssue{File: path, Text: found.Text}
+		issue.Line, issue.Column = lines.position(found.Start)
+
+		if found.Err != nil {
+			issue.Message = "unsupported color syntax, not checked"
+			issue.Warning = true
+			issues = append(issues, issue)
+			continue
+		}
+
+		nearest := rank(found.Color, palette, metric)[0]
+
+		if nearest.Distance <= maxDistance {
+			continue
+		}
+
+		issue.Nearest = &nearest
+		issue.Message = fmt.Sprintf("not in palette, nearest is %s %s at distance %g", nearest.Name, nearest.Color, nearest.Distance)
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+func runLint(e *env, args []string) int {
+
+	fs := newFlagSet(e, "lint")
+	palettePath := fs.String("palette", "", "palette `FILE` colors must be taken from")
+	metricName := fs.String("metric", "ciede2000", "distance `METRIC`: ciede2000, oklab or rgb")
+	maxDistance := fs.Float64("max", -1, "maximum `DISTANCE` from the palette, by default about a just noticeable difference of the metric")
+	asJSON := fs.Bool("json", false, "write each issue as a JSON object on its own line")
+
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return flagError(err)
+	}
+
+	if len(args) == 0 {
+		fs.Usage()
+		return exitError
+	}
+
+	metric, err := parseMetric(*metricName)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "colors: %v\n", err)
+		return exitError
+	}
+
+	if *maxDistance < 0 {
+		*maxDistance = noticeable[metric]
+	}
+
+	palette, ok := loadPalette(e, *palettePath)
+	if !ok {
+		return exitError
+	}
+
+	files, err := expandPatterns(args)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "colors: %v\n", err)
+		return exitError
+	}
+
+	enc := json.NewEncoder(e.stdout)
+	code := exitOK
+
+	for _, file := range files {
+
+		issues, err := lintFile(file, palette, metric, *maxDistance)
+		if err != nil {
+			fmt.Fprintf(e.stderr, "colors: %v\n", err)
+			return exitError
+		}
+
+		for _, issue := range issues {
+
+			prefix := "warning: "
+
+			if !issue.Warning {
+				prefix = ""
+				code = exitFail
+			}
+
+			if *asJSON {
+				if err := enc.Encode(issue); err != nil {
+					fmt.Fprintf(e.stderr, "colors: %v\n", err)
+					return exitError
+				}
+				continue
+			}
+
+			fmt.Fprintf(e.stdout, "%s:%d:%d: %s%s: %s\n", issue.File, issue.Line, issue.Column, prefix, issue.Text, issue.Message)
+		}
+	}
+
+	return code
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/colors"
+)
+
+func TestLint(t *testing.T) {
+
+	dir := t.TempDir()
+	brand := writeFile(t, dir, "brand.json", brandTokens)
+	css := writeFile(t, dir, "src/a/x.css", "/* #000 */\n.a {\n  color: #123457;\n  background: #00ff00;\n  border-color: white;\n  fill: hsl(1 2% 3%);\n}\n")
+	writeFile(t, dir, "src/y.scss", "$accent: #FF8800;\n")
+
+	code, out, _ := runTest(colors.ANSINone, "lint", "-palette", brand, filepath.Join(dir, "src/**/*.css"), filepath.Join(dir, "src/**/*.scss"))
+
+	expected := css + ":4:15: #00ff00: not in palette, nearest is brand.white #ffffff at distance 33.2554\n" +
+		css + ":6:9: warning: hsl(1 2% 3%): unsupported color syntax, not checked\n"
+
+	if code != exitFail || out != expected {
+		t.Errorf("unexpected lint %d:\n%s\nexpected:\n%s", code, out, expected)
+	}
+
+	code, out, _ = runTest(colors.ANSINone, "lint", "-palette", brand, "-json", "-max", "40", filepath.Join(dir, "src", "a", "x.css"))
+
+	var issue lintIssue
+
+	if err := json.Unmarshal([]byte(out), &issue); err != nil {
+		t.Fatal(err)
+	}
+
+	// warnings alone don't fail the lint
+	if code != exitOK || strings.Count(out, "\n") != 1 || issue.Line != 6 || issue.Column != 9 || !issue.Warning || issue.Nearest != nil {
+		t.Errorf("unexpected lint %d: %s", code, out)
+	}
+
+	if code, out, _ = runTest(colors.ANSINone, "lint", "-palette", brand, filepath.Join(dir, "src", "*.scss")); code != exitOK || out != "" {
+		t.Errorf("expected a clean lint, got %d: %s", code, out)
+	}
+
+	if code, _, stderr := runTest(colors.ANSINone, "lint", "-palette", brand, filepath.Join(dir, "**/*.less")); code != exitError || !strings.Contains(stderr, "no files match") {
+		t.Errorf("expected exit %d when nothing matches, got %d: %s", exitError, code, stderr)
+	}
+}
+
+func TestMatchSegments(t *testing.T) {
+
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"**/*.css", "a.css", true},
+		{"**/*.css", "a/b/c.css", true},
+		{"a/**/c.css", "a/c.css", true},
+		{"a/**/c.css", "a/b/b/c.css", true},
+		{"a/**/c.css", "b/c.css", false},
+		{"*.css", "a/b.css", false},
+		{"**", "a/b.css", true},
+	}
+
+	for _, tt := range tests {
+		if got := matchSegments(strings.Split(tt.pattern, "/"), strings.Split(tt.path, "/")); got != tt.match {
+			t.Errorf("%s %s: expected %t, got %t", tt.pattern, tt.path, tt.match, got)
+		}
+	}
+}
+
+func TestExpandPatterns(t *testing.T) {
+
+	dir := t.TempDir()
+	a := writeFile(t, dir, "a.css", "")
+	b := writeFile(t, dir, "x/y/b.css", "")
+	writeFile(t, dir, "x/c.scss", "")
+
+	files, err := expandPatterns([]string{filepath.Join(dir, "**", "*.css"), a, "missing.css"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{a, b, "missing.css"}; !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, got %v", expected, files)
+	}
+
+	if _, err = expandPatterns([]string{filepath.Join(dir, "[")}); err == nil {
+		t.Error("expected an invalid pattern error")
+	}
+}
@@ -0,0 +1,205 @@
+// Command colors converts, inspects and compares colors from the command line.
+//
+// Usage:
+//
+//	colors [inspect] [-json] [-swatch=false] COLOR...
+//	colors contrast [-json] [-min RATIO] FOREGROUND BACKGROUND
+//	colors nearest -palette FILE [-metric METRIC] [-n COUNT] [-max DISTANCE] [-json] COLOR
+//	colors lint -palette FILE [-metric METRIC] [-max DISTANCE] [-json] FILE|PATTERN...
+//	colors palette convert [-from FORMAT] [-to FORMAT] [-name NAME] IN OUT
+//
+// COLOR is any string understood by colors.ParseLenient, e.g. #ff8800, ff8800 or rebeccapurple.
+// With -json each result is written as a JSON object on its own line.
+//
+// Palettes are read and written according to their extension: .ase, .aco, .gpl, .pal (JASC),
+// .txt (Paint.NET) and .json (design tokens), and may also be written as .css or .scss.
+// lint scans CSS, SCSS and LESS files for colors further than -max from every palette color,
+// PATTERN supporting ** to match any number of directories, e.g. 'src/**/*.css'.
+// Colors it can't parse, such as lab(), are reported as warnings without failing the lint.
+//
+// Exit codes are 0 on success, 1 when a check fails, e.g. contrast below -min or colors
+// found by lint, and 2 on invalid usage, colors or files.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/colors"
+)
+
+// Exit codes
+const (
+	exitOK    = 0
+	exitFail  = 1
+	exitError = 2 // invalid usage, input or I/O
+)
+
+// env is what commands read from and write to
+type env struct {
+	stdout io.Writer
+	stderr io.Writer
+
+	// mode is the color support of stdout used for swatches
+	mode colors.ANSIMode
+}
+
+// command runs a subcommand with its arguments, returning the exit code
+type command struct {
+	usage string
+	run   func(e *env, args []string) int
+}
+
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"inspect":  {usage: "[-json] [-swatch=false] COLOR...", run: runInspect},
+		"contrast": {usage: "[-json] [-min RATIO] FOREGROUND BACKGROUND", run: runContrast},
+		"nearest":  {usage: "-palette FILE [-metric METRIC] [-n COUNT] [-max DISTANCE] [-json] COLOR", run: runNearest},
+		"lint":     {usage: "-palette FILE [-metric METRIC] [-max DISTANCE] [-json] FILE|PATTERN...", run: runLint},
+		"palette":  {usage: "convert [-from FORMAT] [-to FORMAT] [-name NAME] IN OUT", run: runPalette},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], &env{stdout: os.Stdout, stderr: os.Stderr, mode: colors.DetectANSIMode(os.Stdout)}))
+}
+
+// run dispatches args to a command, colors being inspected when no command is named
+func run(args []string, e *env) int {
+
+	if len(args) == 0 {
+		usage(e.stderr)
+		return exitError
+	}
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		usage(e.stdout)
+		return exitOK
+	}
+
+	if cmd, ok := commands[args[0]]; ok {
+		return cmd.run(e, args[1:])
+	}
+
+	return runInspect(e, args)
+}
+
+func usage(w io.Writer) {
+
+	names := make([]string, 0, len(commands))
+
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "usage:")
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  colors %s %s\n", name, commands[name].usage)
+	}
+}
+
+// newFlagSet returns a FlagSet for the command that reports errors to e.stderr
+func newFlagSet(e *env, name string) *flag.FlagSet {
+
+	fs := flag.NewFlagSet("colors "+name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "usage: colors %s %s\n", name, commands[name].usage)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+// parseFlags parses args allowing flags to follow positional arguments,
+// e.g. colors contrast '#fff' '#000' -json, until a -- terminator
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+
+	var positional []string
+
+	for {
+
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		if parsed := len(args) - fs.NArg(); parsed > 0 && args[parsed-1] == "--" {
+			return append(positional, fs.Args()...), nil
+		}
+
+		args = fs.Args()
+
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseColor parses s using colors.ParseLenient, naming s when it is invalid
+func parseColor(s string) (colors.Color, error) {
+
+	c, err := colors.ParseLenient(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %q", strings.TrimSpace(s))
+	}
+
+	return c, nil
+}
+
+// parseColors parses every argument, reporting the first invalid color
+func parseColors(e *env, args []string) ([]colors.Color, bool) {
+
+	parsed := make([]colors.Color, 0, len(args))
+
+	for _, s := range args {
+
+		c, err := parseColor(s)
+		if err != nil {
+			fmt.Fprintf(e.stderr, "colors: %v\n", err)
+			return nil, false
+		}
+
+		parsed = append(parsed, c)
+	}
+
+	return parsed, true
+}
+
+// flagError returns the exit code of a flag parsing error
+func flagError(err error) int {
+
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	return exitError
+}
+
+// swatch returns text painted on the color, or an empty string when stdout has no color support
+func (e *env) swatch(text string, fg, bg colors.Color) string {
+
+	if e.mode == colors.ANSINone {
+		return ""
+	}
+
+	var buf strings.Builder
+
+	w := colors.NewANSIWriter(&buf, e.mode)
+	_, _ = io.WriteString(w, colors.Colorize(text, fg, bg))
+
+	return buf.String()
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/colors"
+)
+
+// runTest runs the command line, returning the exit code, stdout and stderr
+func runTest(mode colors.ANSIMode, args ...string) (int, string, string) {
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(args, &env{stdout: &stdout, stderr: &stderr, mode: mode})
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestInspect(t *testing.T) {
+
+	code, out, _ := runTest(colors.ANSINone, "#ff8800")
+
+	if code != exitOK {
+		t.Fatalf("expected exit %d, got %d", exitOK, code)
+	}
+
+	for _, line := range []string{"hex        #ff8800", "rgb        rgb(255,136,0)", "hsl        hsl(32,100%,50%)", "light      true"} {
+		if !strings.Contains(out, line) {
+			t.Errorf("expected output to contain %q, got:\n%s", line, out)
+		}
+	}
+
+	if strings.Contains(out, "swatch") {
+		t.Errorf("expected no swatch without color support, got:\n%s", out)
+	}
+
+	code, out, _ = runTest(colors.ANSI256, "inspect", "red")
+
+	if code != exitOK || !strings.Contains(out, "swatch     \x1b[48;5;196m") {
+		t.Errorf("expected a 256 color swatch, got %d:\n%q", code, out)
+	}
+
+	_, out, _ = runTest(colors.ANSITrueColor, "red", "-swatch=false")
+
+	if strings.Contains(out, "swatch") {
+		t.Errorf("expected -swatch=false to disable the swatch, got:\n%s", out)
+	}
+}
+
+func TestInspectJSON(t *testing.T) {
+
+	code, out, _ := runTest(colors.ANSITrueColor, "-json", "rgba(0,0,255,0.5)", "white")
+
+	if code != exitOK {
+		t.Fatalf("expected exit %d, got %d", exitOK, code)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(out))
+
+	var in inspection
+
+	if err := dec.Decode(&in); err != nil {
+		t.Fatal(err)
+	}
+
+	if in.HEX != "#0000ff80" || in.HSL != "hsla(240,100%,50%,0.5)" || in.Luminance != 0.0722 || !in.Dark {
+		t.Errorf("unexpected inspection %+v", in)
+	}
+
+	if err := dec.Decode(&in); err != nil {
+		t.Fatal(err)
+	}
+
+	if in.Input != "white" || in.HEX != "#ffffff" || in.Luminance != 1 || !in.Light {
+		t.Errorf("unexpected inspection %+v", in)
+	}
+}
+
+func TestContrast(t *testing.T) {
+
+	code, out, _ := runTest(colors.ANSINone, "contrast", "#767676", "white")
+
+	if code != exitOK || !strings.Contains(out, "ratio      4.54:1\nAA         pass\n") || !strings.Contains(out, "AAA        fail") {
+		t.Errorf("unexpected contrast %d:\n%s", code, out)
+	}
+
+	code, out, _ = runTest(colors.ANSINone, "contrast", "-json", "-min", "7", "#767676", "#fff")
+
+	var report contrastReport
+
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatal(err)
+	}
+
+	if code != exitFail || report.Ratio != 4.54 || report.Pass || !report.AA {
+		t.Errorf("unexpected contrast %d: %+v", code, report)
+	}
+
+	code, _, _ = runTest(colors.ANSINone, "contrast", "#000", "#fff", "-min", "7")
+
+	if code != exitOK {
+		t.Errorf("expected exit %d, got %d", exitOK, code)
+	}
+}
+
+func TestUsage(t *testing.T) {
+
+	if code, _, _ := runTest(colors.ANSINone); code != exitError {
+		t.Errorf("expected exit %d without arguments, got %d", exitError, code)
+	}
+
+	if code, _, stderr := runTest(colors.ANSINone, "#nope"); code != exitError || stderr != "colors: invalid color \"#nope\"\n" {
+		t.Errorf("expected exit %d for an invalid color, got %d: %s", exitError, code, stderr)
+	}
+
+	if code, _, _ := runTest(colors.ANSINone, "contrast", "#fff"); code != exitError {
+		t.Errorf("expected exit %d for a missing color, got %d", exitError, code)
+	}
+
+	if code, _, _ := runTest(colors.ANSINone, "contrast", "-bogus", "#fff", "#000"); code != exitError {
+		t.Errorf("expected exit %d for an unknown flag, got %d", exitError, code)
+	}
+
+	if code, out, _ := runTest(colors.ANSINone, "help"); code != exitOK || !strings.HasPrefix(out, "usage:") {
+		t.Errorf("expected usage, got %d: %s", code, out)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/colors"
+)
+
+// metrics are the distance metrics selectable by name
+var metrics = map[string]colors.DistanceMetric{
+	"ciede2000": colors.DistanceCIEDE2000,
+	"oklab":     colors.DistanceOKLab,
+	"rgb":       colors.DistanceRGB,
+}
+
+// noticeable is roughly the smallest noticeable difference of each metric, used as the default -max
+var noticeable = map[colors.DistanceMetric]float64{
+	colors.DistanceCIEDE2000: 2.3,
+	colors.DistanceOKLab:     0.02,
+	colors.DistanceRGB:       10,
+}
+
+func parseMetric(name string) (colors.DistanceMetric, error) {
+
+	m, ok := metrics[strings.ToLower(name)]
+	if !ok {
+		return m, fmt.Errorf("unknown metric %q, expected ciede2000, oklab or rgb", name)
+	}
+
+	return m, nil
+}
+
+// match is a palette color and its distance from the color being matched
+type match struct {
+	Name     string  `json:"name"`
+	Color    string  `json:"color"`
+	Distance float64 `json:"distance"`
+}
+
+// rank returns the palette colors ordered by their distance to c. Colors Equal to c always
+// have a distance of 0 and alpha is ignored, translucent colors matching their opaque equivalent
+func rank(c colors.Color, palette []colors.NamedColor, metric colors.DistanceMetric) []match {
+
+	rgb := c.ToRGB()
+	matches := make([]match, len(palette))
+
+	for i, p := range palette {
+
+		m := match{Name: p.Name, Color: p.Color.String()}
+
+		if !rgb.Equal(p.Color.ToRGB()) {
+			m.Distance = round(metric.Distance(rgb, p.Color), 4)
+		}
+
+		matches[i] = m
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Distance < matches[j].Distance
+	})
+
+	return matches
+}
+
+// loadPalette reads the -palette flag's palette, reporting errors to e.stderr
+func loadPalette(e *env, path string) ([]colors.NamedColor, bool) {
+
+	if path == "" {
+		fmt.Fprintln(e.stderr, "colors: -palette is required")
+		return nil, false
+	}
+
+	p, err := readPalette(path, "")
+	if err != nil {
+		fmt.Fprintf(e.stderr, "colors: %v\n", err)
+		return nil, false
+	}
+
+	if len(p.colors) == 0 {
+		fmt.Fprintf(e.stderr, "colors: %s: palette has no colors\n", path)
+		return nil, false
+	}
+
+	return p.colors, true
+}
+
+func runNearest(e *env, args []string) int {
+
+	fs := newFlagSet(e, "nearest")
+	palettePath := fs.String("palette", "", "palette `FILE` to search")
+	metricName := fs.String("metric", "ciede2000", "distance `METRIC`: ciede2000, oklab or rgb")
+	count := fs.Int("n", 1, "number of nearest colors to list")
+	maxDistance := fs.Float64("max", -1, "exit with status 1 when the nearest color is further than `DISTANCE`")
+	asJSON := fs.Bool("json", false, "write the matches as a JSON array")
+
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return flagError(err)
+	}
+
+	if len(args) != 1 || *count < 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	metric, err := parseMetric(*metricName)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "colors: %v\n", err)
+		return exitError
+	}
+
+	c, err := parseColor(args[0])
+	if err != nil {
+		fmt.Fprintf(e.stderr, "colors: %v\n", err)
+		return exitError
+	}
+
+	palette, ok := loadPalette(e, *palettePath)
+	if !ok {
+		return exitError
+	}
+
+	matches := rank(c, palette, metric)
+
+	if len(matches) > *count {
+		matches = matches[:*count]
+	}
+
+	if *asJSON {
+		if err := json.NewEncoder(e.stdout).Encode(matches); err != nil {
+			fmt.Fprintf(e.stderr, "colors: %v\n", err)
+			return exitError
+		}
+	} else {
+		for _, m := range matches {
+			fmt.Fprintf(e.stdout, "%s\t%s\t%g\n", m.Name, m.Color, m.Distance)
+		}
+	}
+
+	if *maxDistance >= 0 && matches[0].Distance > *maxDistance {
+		return exitFail
+	}
+
+	return exitOK
+}
+
+// round rounds v to the given number of decimals
+func round(v float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(v*p) / p
+}
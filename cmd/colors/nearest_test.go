@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-playground/colors"
+)
+
+func TestNearest(t *testing.T) {
+
+	brand := writeFile(t, t.TempDir(), "brand.json", brandTokens)
+
+	code, out, _ := runTest(colors.ANSINone, "nearest", "#123456", "-palette", brand)
+
+	if code != exitOK || out != "brand.primary\t#123456\t0\n" {
+		t.Errorf("unexpected nearest %d: %q", code, out)
+	}
+
+	code, out, _ = runTest(colors.ANSINone, "nearest", "-json", "-n", "2", "-metric", "oklab", "-palette", brand, "rgba(255,255,255,0.5)")
+
+	var matches []match
+
+	if err := json.Unmarshal([]byte(out), &matches); err != nil {
+		t.Fatal(err)
+	}
+
+	if code != exitOK || len(matches) != 2 || matches[0] != (match{Name: "brand.white", Color: "#ffffff"}) || matches[1].Name != "brand.accent" {
+		t.Errorf("unexpected nearest %d: %+v", code, matches)
+	}
+
+	if code, _, _ = runTest(colors.ANSINone, "nearest", "#00ff00", "-palette", brand, "-max", "5"); code != exitFail {
+		t.Errorf("expected exit %d beyond -max, got %d", exitFail, code)
+	}
+
+	if code, _, _ = runTest(colors.ANSINone, "nearest", "#00ff00"); code != exitError {
+		t.Errorf("expected exit %d without a palette, got %d", exitError, code)
+	}
+
+	if code, _, _ = runTest(colors.ANSINone, "nearest", "#00ff00", "-palette", brand, "-metric", "cie76"); code != exitError {
+		t.Errorf("expected exit %d for an unknown metric, got %d", exitError, code)
+	}
+}
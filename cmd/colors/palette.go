@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/colors"
+	"github.com/go-playground/colors/tokens"
+)
+
+// paletteFile is a palette read from or written to a file
+type paletteFile struct {
+	name   string
+	colors []colors.NamedColor
+
+	// swatches retains the color models and groups of Adobe swatch files
+	swatches []colors.Swatch
+}
+
+// paletteFormat reads and writes a palette file format, either may be nil when unsupported
+type paletteFormat struct {
+	read  func(r io.Reader) (*paletteFile, error)
+	write func(w io.Writer, p *paletteFile) error
+}
+
+// paletteFormats are the supported palette formats keyed by name, which is also their file extension
+var paletteFormats = map[string]paletteFormat{
+	"ase": {
+		read: func(r io.Reader) (*paletteFile, error) {
+			swatches, err := colors.ReadASE(r)
+			return swatchPalette(swatches), err
+		},
+		write: func(w io.Writer, p *paletteFile) error {
+			return colors.WriteASE(w, p.toSwatches())
+		},
+	},
+	"aco": {
+		read: func(r io.Reader) (*paletteFile, error) {
+			swatches, err := colors.ReadACO(r)
+			return swatchPalette(swatches), err
+		},
+		write: func(w io.Writer, p *paletteFile) error {
+			return colors.WriteACO(w, p.toSwatches())
+		},
+	},
+	"gpl": {
+		read: func(r io.Reader) (*paletteFile, error) {
+			name, named, err := colors.ReadGPL(r)
+			return &paletteFile{name: name, colors: named}, err
+		},
+		write: func(w io.Writer, p *paletteFile) error {
+			return colors.WriteGPL(w, p.name, p.colors)
+		},
+	},
+	"pal": {
+		read: func(r io.Reader) (*paletteFile, error) {
+			named, err := colors.ReadJASC(r)
+			return &paletteFile{colors: named}, err
+		},
+		write: func(w io.Writer, p *paletteFile) error {
+			return colors.WriteJASC(w, p.colors)
+		},
+	},
+	"txt": {
+		read: func(r io.Reader) (*paletteFile, error) {
+			named, err := colors.ReadPaintNET(r)
+			return &paletteFile{colors: named}, err
+		},
+		write: func(w io.Writer, p *paletteFile) error {
+			return colors.WritePaintNET(w, p.colors)
+		},
+	},
+	"json": {
+		read: func(r io.Reader) (*paletteFile, error) {
+			tree, err := tokens.Parse(r)
+			if err != nil {
+				return nil, err
+			}
+			return &paletteFile{colors: tree.Colors()}, nil
+		},
+		write: func(w io.Writer, p *paletteFile) error {
+			tree := tokens.New()
+			for _, c := range p.named() {
+				tree.SetColor(c.Name, c.Color)
+			}
+			return tree.Write(w)
+		},
+	},
+	"css": {
+		write: func(w io.Writer, p *paletteFile) error {
+			return colors.WriteCSSVariables(w, p.named(), colors.Formatter{OmitOpaqueAlpha: true, HexAlpha: true})
+		},
+	},
+	"scss": {
+		write: func(w io.Writer, p *paletteFile) error {
+			return colors.WriteSCSS(w, p.named(), colors.Formatter{OmitOpaqueAlpha: true, HexAlpha: true}, p.name)
+		},
+	},
+}
+
+func swatchPalette(swatches []colors.Swatch) *paletteFile {
+
+	p := &paletteFile{swatches: swatches}
+
+	for _, s := range swatches {
+		p.colors = append(p.colors, s.NamedColor())
+	}
+
+	return p
+}
+
+// toSwatches returns the palette's original swatches or converts its colors to RGB swatches
+func (p *paletteFile) toSwatches() []colors.Swatch {
+
+	if p.swatches != nil {
+		return p.swatches
+	}
+
+	swatches := make([]colors.Swatch, len(p.colors))
+
+	for i, c := range p.colors {
+		swatches[i] = colors.NewSwatch(c.Name, c.Color)
+	}
+
+	return swatches
+}
+
+// named returns the palette's colors, naming any unnamed colors by position for formats requiring names
+func (p *paletteFile) named() []colors.NamedColor {
+
+	named := make([]colors.NamedColor, len(p.colors))
+
+	for i, c := range p.colors {
+		if strings.TrimSpace(c.Name) == "" {
+			c.Name = fmt.Sprintf("color-%d", i+1)
+		}
+		named[i] = c
+	}
+
+	return named
+}
+
+// formatNames returns the sorted names of formats supporting reading or writing
+func formatNames(writable bool) string {
+
+	var names []string
+
+	for name, f := range paletteFormats {
+		if (writable && f.write != nil) || (!writable && f.read != nil) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// lookupFormat returns the named format, or the format of the path's extension when name is empty
+func lookupFormat(name, path string, writable bool) (paletteFormat, error) {
+
+	if name == "" {
+		name = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	f, ok := paletteFormats[name]
+
+	if !ok || (writable && f.write == nil) || (!writable && f.read == nil) {
+
+		verb := "read"
+		if writable {
+			verb = "write"
+		}
+
+		return f, fmt.Errorf("cannot %s palette format %q of %s, supported formats are %s", verb, name, path, formatNames(writable))
+	}
+
+	return f, nil
+}
+
+// readPalette reads the palette at path, "-" reading stdin, in the given format or the format of its extension
+func readPalette(path, format string) (*paletteFile, error) {
+
+	f, err := lookupFormat(format, path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.Reader = os.Stdin
+
+	if path != "-" {
+
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		defer file.Close()
+		r = file
+	}
+
+	p, err := f.read(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if p.name == "" && path != "-" {
+		p.name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	return p, nil
+}
+
+// writePalette writes the palette to path, "-" writing to w, in the given format or the format of its extension
+func writePalette(w io.Writer, path, format string, p *paletteFile) error {
+
+	f, err := lookupFormat(format, path, true)
+	if err != nil {
+		return err
+	}
+
+	if path == "-" {
+		return f.write(w, p)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err = f.write(file, p); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func runPalette(e *env, args []string) int {
+
+	if len(args) == 0 || args[0] != "convert" {
+		fmt.Fprintf(e.stderr, "usage: colors palette %s\n", commands["palette"].usage)
+		return exitError
+	}
+
+	fs := newFlagSet(e, "palette")
+	from := fs.String("from", "", "input `FORMAT`, by default the input's extension: "+formatNames(false))
+	to := fs.String("to", "", "output `FORMAT`, by default the output's extension: "+formatNames(true))
+	name := fs.String("name", "", "palette `NAME` written to formats supporting one, by default the input's name")
+
+	args, err := parseFlags(fs, args[1:])
+	if err != nil {
+		return flagError(err)
+	}
+
+	if len(args) != 2 {
+		fs.Usage()
+		return exitError
+	}
+
+	if _, err = lookupFormat(*to, args[1], true); err != nil {
+		fmt.Fprintf(e.stderr, "colors: %v\n", err)
+		return exitError
+	}
+
+	p, err := readPalette(args[0], *from)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "colors: %v\n", err)
+		return exitError
+	}
+
+	if *name != "" {
+		p.name = *name
+	}
+
+	if err = writePalette(e.stdout, args[1], *to, p); err != nil {
+		fmt.Fprintf(e.stderr, "colors: %v\n", err)
+		return exitError
+	}
+
+	return exitOK
+}
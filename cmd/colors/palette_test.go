@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/colors"
+)
+
+const brandTokens = `{
+  "brand": {
+    "$type": "color",
+    "primary": { "$value": "#123456" },
+    "accent": { "$value": "#ff8800" },
+    "white": { "$value": "#ffffff" }
+  }
+}`
+
+// writeFile writes a file within dir, creating any parent directories, and returns its path
+func writeFile(t *testing.T, dir, name, content string) string {
+
+	t.Helper()
+
+	path := filepath.Join(dir, filepath.FromSlash(name))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestPaletteConvert(t *testing.T) {
+
+	dir := t.TempDir()
+	brand := writeFile(t, dir, "brand.json", brandTokens)
+	ase := filepath.Join(dir, "brand.ase")
+
+	if code, _, stderr := runTest(colors.ANSINone, "palette", "convert", brand, ase); code != exitOK {
+		t.Fatalf("expected exit %d, got %d: %s", exitOK, code, stderr)
+	}
+
+	code, out, stderr := runTest(colors.ANSINone, "palette", "convert", "-name", "Brand", ase, "-", "-to", "gpl")
+
+	if code != exitOK {
+		t.Fatalf("expected exit %d, got %d: %s", exitOK, code, stderr)
+	}
+
+	expected := "GIMP Palette\nName: Brand\n#\n 18  52  86\tbrand.primary\n255 136   0\tbrand.accent\n255 255 255\tbrand.white\n"
+
+	if out != expected {
+		t.Errorf("expected %q, got %q", expected, out)
+	}
+
+	code, out, _ = runTest(colors.ANSINone, "palette", "convert", brand, "-", "-to", "css")
+
+	if code != exitOK || !strings.Contains(out, "  --brand-accent: #ff8800;\n") {
+		t.Errorf("unexpected css %d: %s", code, out)
+	}
+}
+
+func TestPaletteConvertErrors(t *testing.T) {
+
+	dir := t.TempDir()
+	brand := writeFile(t, dir, "brand.json", brandTokens)
+
+	tests := []struct {
+		args   []string
+		stderr string
+	}{
+		{[]string{"palette", "convert", brand, filepath.Join(dir, "out.foo")}, `cannot write palette format "foo"`},
+		{[]string{"palette", "convert", filepath.Join(dir, "brand.css"), "-", "-to", "gpl"}, `cannot read palette format "css"`},
+		{[]string{"palette", "convert", filepath.Join(dir, "missing.gpl"), "-", "-to", "gpl"}, "missing.gpl"},
+		{[]string{"palette", "convert", brand}, "usage: colors palette"},
+		{[]string{"palette", "export"}, "usage: colors palette"},
+	}
+
+	for _, tt := range tests {
+
+		code, _, stderr := runTest(colors.ANSINone, tt.args...)
+
+		if code != exitError || !strings.Contains(stderr, tt.stderr) {
+			t.Errorf("%v: expected exit %d and %q, got %d: %s", tt.args, exitError, tt.stderr, code, stderr)
+		}
+	}
+}
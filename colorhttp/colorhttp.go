@@ -0,0 +1,414 @@
+// Package colorhttp serves color conversion, contrast, swatch and palette endpoints over HTTP
+// using only the standard library.
+//
+//	GET /convert?c=COLOR
+//	GET /contrast?fg=COLOR&bg=COLOR
+//	GET /swatch/{colors}.png
+//	GET /swatch/{colors}.svg
+//	GET /palette?c=COLOR[&harmony=NAME]
+//
+// COLOR is any string understood by colors.ParseLenient, which accepts hex digits without
+// the leading # that would otherwise need escaping as %23. Swatch paths take one or more
+// comma separated colors, e.g. /swatch/ff8800,123456.svg, and accept the query parameters
+// size, layout (strip, grid or wheel), columns, gap, labels (hex, contrast or hex,contrast),
+// contrast (the color contrast labels are measured against), bg (the background color) and
+// gradient=true to render a gradient through the colors.
+//
+// Errors are returned as a JSON object with an error message, e.g. {"error":"invalid color \"nope\""}.
+// Use http.StripPrefix to serve the endpoints below a path prefix.
+package colorhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/colors"
+)
+
+// Default limits
+const (
+	defaultMaxSize   = 512
+	defaultMaxColors = 64
+	defaultMaxPixels = 4 << 20
+)
+
+var layouts = map[string]colors.Layout{
+	"strip": colors.LayoutStrip,
+	"grid":  colors.LayoutGrid,
+	"wheel": colors.LayoutWheel,
+}
+
+var labels = map[string]colors.Label{
+	"hex":      colors.LabelHex,
+	"contrast": colors.LabelContrast,
+}
+
+// Handler is an http.Handler serving the color endpoints, its zero value using the default limits
+type Handler struct {
+	// MaxSize is the largest swatch size in pixels that may be requested, 0 using 512
+	MaxSize int
+
+	// MaxColors is the most colors a single swatch image may render, 0 using 64
+	MaxColors int
+
+	// MaxPixels is the largest width × height of a swatch image, 0 using 4 megapixels
+	MaxPixels int
+}
+
+// Conversion is the response of /convert, the color in every supported notation
+type Conversion struct {
+	Input string `json:"input"`
+	colors.Inspection
+}
+
+// ContrastResult is the response of /contrast, the WCAG 2 contrast of a foreground over a background
+type ContrastResult struct {
+	Foreground string `json:"foreground"`
+	Background string `json:"background"`
+	colors.ContrastLevels
+}
+
+// PaletteResult is the response of /palette, the hex colors of each requested harmony keyed by name
+type PaletteResult struct {
+	Input     string              `json:"input"`
+	Harmonies map[string][]string `json:"harmonies"`
+}
+
+// requestError is a client error reported with a status code
+type requestError struct {
+	status  int
+	message string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &requestError{status: http.StatusBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+// ServeHTTP implements http.Handler
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, &requestError{status: http.StatusMethodNotAllowed, message: "method not allowed"})
+		return
+	}
+
+	var err error
+
+	switch path := r.URL.Path; {
+	case path == "/convert":
+		err = h.convert(w, r)
+	case path == "/contrast":
+		err = h.contrast(w, r)
+	case path == "/palette":
+		err = h.palette(w, r)
+	case strings.HasPrefix(path, "/swatch/"):
+		err = h.swatch(w, r, strings.TrimPrefix(path, "/swatch/"))
+	default:
+		err = &requestError{status: http.StatusNotFound, message: "not found"}
+	}
+
+	if err != nil {
+		writeError(w, err)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+
+	status := http.StatusInternalServerError
+
+	if re, ok := err.(*requestError); ok {
+		status = re.status
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(v)
+}
+
+// parseColor parses s using colors.ParseLenient, reporting an invalid color as a bad request
+func parseColor(s string) (colors.Color, error) {
+
+	c, err := colors.ParseLenient(s)
+	if err != nil {
+		return nil, badRequest("invalid color %q", strings.TrimSpace(s))
+	}
+
+	return c, nil
+}
+
+// queryColor parses the required color query parameter
+func queryColor(r *http.Request, param string) (colors.Color, error) {
+
+	s := r.URL.Query().Get(param)
+
+	if s == "" {
+		return nil, badRequest("missing %s parameter", param)
+	}
+
+	return parseColor(s)
+}
+
+// queryInt parses the optional integer query parameter, returning def when it is absent
+func queryInt(r *http.Request, param string, def, lo, hi int) (int, error) {
+
+	s := r.URL.Query().Get(param)
+
+	if s == "" {
+		return def, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < lo || v > hi {
+		return 0, badRequest("%s must be a whole number from %d to %d", param, lo, hi)
+	}
+
+	return v, nil
+}
+
+func (h Handler) convert(w http.ResponseWriter, r *http.Request) error {
+
+	c, err := queryColor(r, "c")
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, Conversion{Input: r.URL.Query().Get("c"), Inspection: colors.Inspect(c)})
+}
+
+func (h Handler) contrast(w http.ResponseWriter, r *http.Request) error {
+
+	fg, err := queryColor(r, "fg")
+	if err != nil {
+		return err
+	}
+
+	bg, err := queryColor(r, "bg")
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, ContrastResult{
+		Foreground:     r.URL.Query().Get("fg"),
+		Background:     r.URL.Query().Get("bg"),
+		ContrastLevels: colors.CheckContrast(fg, bg),
+	})
+}
+
+func (h Handler) palette(w http.ResponseWriter, r *http.Request) error {
+
+	c, err := queryColor(r, "c")
+	if err != nil {
+		return err
+	}
+
+	harmonies := []colors.Harmony{
+		colors.HarmonyComplementary,
+		colors.HarmonyAnalogous,
+		colors.HarmonyTriadic,
+		colors.HarmonySplitComplementary,
+		colors.HarmonyTetradic,
+		colors.HarmonyMonochromatic,
+	}
+
+	if name := r.URL.Query().Get("harmony"); name != "" {
+
+		harmony, err := colors.ParseHarmony(name)
+		if err != nil {
+			return badRequest("unknown harmony %q", name)
+		}
+
+		harmonies = []colors.Harmony{harmony}
+	}
+
+	result := PaletteResult{Input: r.URL.Query().Get("c"), Harmonies: make(map[string][]string)}
+	f := colors.Formatter{HexAlpha: true}
+
+	for _, harmony := range harmonies {
+
+		var hexes []string
+
+		for _, hc := range colors.Harmonize(c, harmony) {
+			hexes = append(hexes, f.ToString(hc))
+		}
+
+		result.Harmonies[harmony.String()] = hexes
+	}
+
+	return writeJSON(w, result)
+}
+
+func (h Handler) swatch(w http.ResponseWriter, r *http.Request, name string) error {
+
+	var contentType string
+
+	switch {
+	case strings.HasSuffix(name, ".png"):
+		contentType = "image/png"
+	case strings.HasSuffix(name, ".svg"):
+		contentType = "image/svg+xml"
+	default:
+		return &requestError{status: http.StatusNotFound, message: "swatches must end in .png or .svg"}
+	}
+
+	specs := strings.Split(name[:len(name)-len(".png")], ",")
+
+	if len(specs) > h.maxColors() {
+		return badRequest("at most %d colors may be rendered", h.maxColors())
+	}
+
+	swatches := make([]colors.Color, len(specs))
+
+	for i, spec := range specs {
+
+		c, err := parseColor(spec)
+		if err != nil {
+			return err
+		}
+
+		swatches[i] = c
+	}
+
+	renderer, err := h.renderer(r)
+	if err != nil {
+		return err
+	}
+
+	// gradients render the same image whatever the number of stops
+	gradient := r.URL.Query().Get("gradient") == "true"
+	n := len(swatches)
+
+	if gradient {
+		n = 0
+	}
+
+	if b := renderer.Bounds(n); b.Dx()*b.Dy() > h.maxPixels() {
+		return badRequest("swatch images may be at most %d pixels, requested %dx%d", h.maxPixels(), b.Dx(), b.Dy())
+	}
+
+	var buf bytes.Buffer
+
+	switch {
+	case contentType == "image/png" && gradient:
+		err = renderer.WriteGradientPNG(&buf, swatches)
+	case contentType == "image/png":
+		err = renderer.WritePNG(&buf, swatches)
+	case gradient:
+		err = renderer.WriteGradientSVG(&buf, swatches)
+	default:
+		err = renderer.WriteSVG(&buf, swatches)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+
+	// swatches are fully determined by their URL
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+
+	_, err = buf.WriteTo(w)
+
+	return err
+}
+
+// renderer returns the Renderer configured by the request's query parameters
+func (h Handler) renderer(r *http.Request) (colors.Renderer, error) {
+
+	q := r.URL.Query()
+
+	var renderer colors.Renderer
+	var err error
+
+	if renderer.Size, err = queryInt(r, "size", 64, 8, h.maxSize()); err != nil {
+		return renderer, err
+	}
+
+	if renderer.Columns, err = queryInt(r, "columns", 0, 0, h.maxColors()); err != nil {
+		return renderer, err
+	}
+
+	// gaps are bounded by the swatch size so they can't inflate the image on their own
+	if renderer.Gap, err = queryInt(r, "gap", 0, 0, renderer.Size/4); err != nil {
+		return renderer, err
+	}
+
+	if name := q.Get("layout"); name != "" {
+
+		layout, ok := layouts[name]
+		if !ok {
+			return renderer, badRequest("layout must be strip, grid or wheel")
+		}
+
+		renderer.Layout = layout
+	}
+
+	if names := q.Get("labels"); names != "" {
+		for _, name := range strings.Split(names, ",") {
+
+			label, ok := labels[name]
+			if !ok {
+				return renderer, badRequest("labels must be hex, contrast or hex,contrast")
+			}
+
+			renderer.Labels |= label
+		}
+	}
+
+	if s := q.Get("contrast"); s != "" {
+		if renderer.ContrastWith, err = parseColor(s); err != nil {
+			return renderer, err
+		}
+	}
+
+	if s := q.Get("bg"); s != "" {
+		if renderer.Background, err = parseColor(s); err != nil {
+			return renderer, err
+		}
+	}
+
+	return renderer, nil
+}
+
+func (h Handler) maxSize() int {
+
+	if h.MaxSize <= 0 {
+		return defaultMaxSize
+	}
+
+	return h.MaxSize
+}
+
+func (h Handler) maxColors() int {
+
+	if h.MaxColors <= 0 {
+		return defaultMaxColors
+	}
+
+	return h.MaxColors
+}
+
+func (h Handler) maxPixels() int {
+
+	if h.MaxPixels <= 0 {
+		return defaultMaxPixels
+	}
+
+	return h.MaxPixels
+}
@@ -0,0 +1,237 @@
+package colorhttp
+
+import (
+	"encoding/json"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/colors"
+)
+
+func serve(h Handler, method, target string) *httptest.ResponseRecorder {
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+
+	return w
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder, v interface{}) {
+
+	t.Helper()
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Content-Type = %q, want application/json", ct)
+	}
+
+	if err := json.NewDecoder(w.Body).Decode(v); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+
+	w := serve(Handler{}, http.MethodGet, "/convert?c=ff8800")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	// inspections are flattened into the response
+	if body := w.Body.String(); !strings.HasPrefix(body, `{"input":"ff8800","hex":"#ff8800",`) {
+		t.Errorf("convert body = %s", body)
+	}
+
+	var got Conversion
+	decode(t, w, &got)
+
+	want := Conversion{
+		Input: "ff8800",
+		Inspection: colors.Inspection{
+			HEX:       "#ff8800",
+			RGB:       "rgb(255,136,0)",
+			RGBA:      "rgba(255,136,0,1)",
+			HSL:       "hsl(32,100%,50%)",
+			OKLCH:     "oklch(74.42% 0.1812 56.46)",
+			ANSI256:   208,
+			Luminance: 0.3887,
+			Light:     true,
+			Channels:  colors.Channels{R: 255, G: 136, A: 1},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("convert = %+v, want %+v", got, want)
+	}
+
+	w = serve(Handler{}, http.MethodGet, "/convert?c=rgba(0,0,255,0.5)")
+	decode(t, w, &got)
+
+	if got.HEX != "#0000ff80" || got.Channels.A != .5 {
+		t.Errorf("convert translucent = %+v", got)
+	}
+}
+
+func TestContrast(t *testing.T) {
+
+	w := serve(Handler{}, http.MethodGet, "/contrast?fg=%23767676&bg=white")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var got ContrastResult
+	decode(t, w, &got)
+
+	want := ContrastResult{
+		Foreground:     "#767676",
+		Background:     "white",
+		ContrastLevels: colors.ContrastLevels{Ratio: 4.54, AA: true, AALarge: true, AAALarge: true},
+	}
+
+	if got != want {
+		t.Errorf("contrast = %+v, want %+v", got, want)
+	}
+}
+
+func TestPalette(t *testing.T) {
+
+	w := serve(Handler{}, http.MethodGet, "/palette?c=ff8800&harmony=complementary")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var got PaletteResult
+	decode(t, w, &got)
+
+	want := PaletteResult{Input: "ff8800", Harmonies: map[string][]string{"complementary": {"#ff8800", "#26b9ff"}}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("palette = %+v, want %+v", got, want)
+	}
+
+	w = serve(Handler{}, http.MethodGet, "/palette?c=ff8800")
+	decode(t, w, &got)
+
+	if len(got.Harmonies) != 6 || len(got.Harmonies["monochromatic"]) != 5 {
+		t.Errorf("palette = %+v, want all 6 harmonies", got)
+	}
+}
+
+func TestSwatch(t *testing.T) {
+
+	w := serve(Handler{}, http.MethodGet, "/swatch/ff8800,123456.png?size=16&labels=hex")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "image/png" {
+		t.Errorf("Content-Type = %q, want image/png", ct)
+	}
+
+	img, err := png.Decode(w.Body)
+	if err != nil {
+		t.Fatalf("invalid PNG: %v", err)
+	}
+
+	if b := img.Bounds(); b.Dx() != 32 || b.Dy() != 16 {
+		t.Errorf("bounds = %v, want 32x16", b)
+	}
+
+	w = serve(Handler{}, http.MethodGet, "/swatch/red,blue.svg?gradient=true&layout=wheel")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "image/svg+xml" {
+		t.Errorf("Content-Type = %q, want image/svg+xml", ct)
+	}
+
+	if !strings.HasPrefix(w.Body.String(), "<svg ") {
+		t.Errorf("body = %q, want an SVG image", w.Body)
+	}
+}
+
+func TestErrors(t *testing.T) {
+
+	tests := []struct {
+		method string
+		target string
+		status int
+		err    string
+	}{
+		{http.MethodGet, "/convert?c=nope", http.StatusBadRequest, `invalid color "nope"`},
+		{http.MethodGet, "/convert", http.StatusBadRequest, "missing c parameter"},
+		{http.MethodGet, "/contrast?fg=red", http.StatusBadRequest, "missing bg parameter"},
+		{http.MethodGet, "/palette?c=red&harmony=clashing", http.StatusBadRequest, `unknown harmony "clashing"`},
+		{http.MethodGet, "/swatch/red.gif", http.StatusNotFound, "swatches must end in .png or .svg"},
+		{http.MethodGet, "/swatch/red.png?size=4096", http.StatusBadRequest, "size must be a whole number from 8 to 512"},
+		{http.MethodGet, "/swatch/red.png?size=16&gap=16", http.StatusBadRequest, "gap must be a whole number from 0 to 4"},
+		{http.MethodGet, "/swatch/" + strings.Repeat("red,", 63) + "red.png?size=512", http.StatusBadRequest, "swatch images may be at most 4194304 pixels, requested 32768x512"},
+		{http.MethodGet, "/swatch/red.png?layout=spiral", http.StatusBadRequest, "layout must be strip, grid or wheel"},
+		{http.MethodGet, "/swatch/red.svg?labels=name", http.StatusBadRequest, "labels must be hex, contrast or hex,contrast"},
+		{http.MethodGet, "/swatch/red,,blue.svg", http.StatusBadRequest, `invalid color ""`},
+		{http.MethodGet, "/unknown", http.StatusNotFound, "not found"},
+		{http.MethodPost, "/convert?c=red", http.StatusMethodNotAllowed, "method not allowed"},
+	}
+
+	for _, tt := range tests {
+
+		w := serve(Handler{}, tt.method, tt.target)
+
+		if w.Code != tt.status {
+			t.Errorf("%s %s: status = %d, want %d", tt.method, tt.target, w.Code, tt.status)
+			continue
+		}
+
+		var got map[string]string
+		decode(t, w, &got)
+
+		if got["error"] != tt.err {
+			t.Errorf("%s %s: error = %q, want %q", tt.method, tt.target, got["error"], tt.err)
+		}
+	}
+
+	w := serve(Handler{MaxColors: 2}, http.MethodGet, "/swatch/red,green,blue.png")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	w = serve(Handler{MaxPixels: 2048}, http.MethodGet, "/swatch/red,blue.png?size=32&gap=1")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	w = serve(Handler{MaxPixels: 2048}, http.MethodGet, "/swatch/red,blue.png?size=32&gradient=true")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	w = serve(Handler{MaxPixels: 2048}, http.MethodGet, "/swatch/red,blue.png?size=32")
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	w = serve(Handler{}, http.MethodPut, "/convert?c=red")
+	if allow := w.Header().Get("Allow"); allow != "GET, HEAD" {
+		t.Errorf("Allow = %q, want GET, HEAD", allow)
+	}
+}
+
+func TestServer(t *testing.T) {
+
+	srv := httptest.NewServer(http.StripPrefix("/colors", Handler{}))
+	defer srv.Close()
+
+	resp, err := http.Head(srv.URL + "/colors/swatch/ff8800.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "image/png" || resp.ContentLength <= 0 {
+		t.Errorf("HEAD = %d %q %d", resp.StatusCode, resp.Header.Get("Content-Type"), resp.ContentLength)
+	}
+}
@@ -55,6 +55,31 @@ func Parse(s string) (Color, error) {
 		return ParseRGBA(s)
 	} else if s[:3] == "rgb" {
 		return ParseRGB(s)
+	} else if strings.HasPrefix(s, "color(") {
+		return ParseSpaceColor(s)
+	}
+
+	return nil, ErrBadColor
+}
+
+// ParseLenient parses the color as Parse does, falling back to CSS named colors and to hex digits
+// without the leading #, e.g. rebeccapurple or ff8800, and ignoring surrounding whitespace.
+// It suits colors typed by people, e.g. command line arguments or URL paths, and returns
+// ErrBadColor when s is none of them
+func ParseLenient(s string) (Color, error) {
+
+	s = strings.TrimSpace(s)
+
+	if c, err := Parse(s); err == nil {
+		return c, nil
+	}
+
+	if c, err := ParseNamed(s); err == nil {
+		return c, nil
+	}
+
+	if c, err := ParseHEX("#" + s); err == nil {
+		return c, nil
 	}
 
 	return nil, ErrBadColor
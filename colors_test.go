@@ -301,6 +301,25 @@ func TestParseColor(t *testing.T) {
 	Equal(t, reflect.TypeOf(c) == reflect.TypeOf(&RGBAColor{}), true)
 }
 
+func TestParseLenient(t *testing.T) {
+
+	for _, s := range []string{"#ff8800", " ff8800\n", "FF8800", "rgb(255,136,0)", "DarkOrange"} {
+		c, err := ParseLenient(s)
+		Equal(t, err, nil)
+		NotEqual(t, c, nil)
+	}
+
+	c, err := ParseLenient("fab")
+	Equal(t, err, nil)
+	Equal(t, c.String(), "#fab")
+
+	_, err = ParseLenient("nope")
+	Equal(t, err, ErrBadColor)
+
+	_, err = ParseLenient("")
+	Equal(t, err, ErrBadColor)
+}
+
 func TestIsLightIsDark(t *testing.T) {
 
 	rgb, _ := RGB(0, 0, 0)
@@ -0,0 +1,81 @@
+package colors
+
+// WCAG 2 contrast ratio thresholds
+const (
+	// ContrastAA is the minimum contrast ratio of normal text for WCAG level AA
+	ContrastAA = 4.5
+
+	// ContrastAALarge is the minimum contrast ratio of large text for WCAG level AA
+	ContrastAALarge = 3.0
+
+	// ContrastAAA is the minimum contrast ratio of normal text for WCAG level AAA
+	ContrastAAA = 7.0
+
+	// ContrastAAALarge is the minimum contrast ratio of large text for WCAG level AAA
+	ContrastAAALarge = 4.5
+)
+
+// Luminance returns the WCAG 2 relative luminance of the color in [0, 1], 0 being black
+// and 1 being white, alpha is discarded
+// https://www.w3.org/TR/WCAG21/#dfn-relative-luminance
+func Luminance(c Color) float64 {
+
+	rgb := c.ToRGB()
+
+	return 0.2126*srgbToLinear(float64(rgb.R)/255) + 0.7152*srgbToLinear(float64(rgb.G)/255) + 0.0722*srgbToLinear(float64(rgb.B)/255)
+}
+
+// Contrast returns the WCAG 2 contrast ratio of fg over bg, in [1, 21]. A translucent fg is
+// composited over bg first, and bg's alpha is discarded
+// https://www.w3.org/TR/WCAG21/#dfn-contrast-ratio
+func Contrast(fg, bg Color) float64 {
+
+	back := bg.ToRGB()
+	l1 := Luminance(composite(fg.ToRGBA(), back))
+	l2 := Luminance(back)
+
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// ContrastLevels is the WCAG 2 contrast ratio of a foreground over a background
+// and the levels it meets
+type ContrastLevels struct {
+	// Ratio is the contrast ratio rounded to 2 decimals, as WCAG tools report it
+	Ratio    float64 `json:"ratio"`
+	AA       bool    `json:"aa"`
+	AALarge  bool    `json:"aaLarge"`
+	AAA      bool    `json:"aaa"`
+	AAALarge bool    `json:"aaaLarge"`
+}
+
+// CheckContrast returns the ContrastLevels of fg over bg, levels being met by the unrounded ratio
+func CheckContrast(fg, bg Color) ContrastLevels {
+
+	ratio := Contrast(fg, bg)
+
+	return ContrastLevels{
+		Ratio:    round(ratio, 2),
+		AA:       ratio >= ContrastAA,
+		AALarge:  ratio >= ContrastAALarge,
+		AAA:      ratio >= ContrastAAA,
+		AAALarge: ratio >= ContrastAAALarge,
+	}
+}
+
+// composite returns c painted over the opaque bg
+func composite(c *RGBAColor, bg *RGBColor) *RGBColor {
+
+	if c.A >= 1 {
+		return c.ToRGB()
+	}
+
+	mix := func(f, b uint8) uint8 {
+		return clampUint8((float64(b) + (float64(f)-float64(b))*c.A) / 255)
+	}
+
+	return &RGBColor{R: mix(c.R, bg.R), G: mix(c.G, bg.G), B: mix(c.B, bg.B)}
+}
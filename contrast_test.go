@@ -0,0 +1,42 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLuminance(t *testing.T) {
+
+	white, _ := ParseHEX("#fff")
+	black, _ := ParseHEX("#000")
+	red, _ := RGBA(255, 0, 0, 0.2)
+
+	Equal(t, Luminance(white), 1.0)
+	Equal(t, Luminance(black), 0.0)
+	Equal(t, Luminance(red), 0.2126)
+}
+
+func TestContrast(t *testing.T) {
+
+	white, _ := ParseHEX("#fff")
+	black, _ := ParseHEX("#000")
+	gray, _ := ParseHEX("#767676")
+	none, _ := RGBA(0, 0, 0, 0)
+	half, _ := RGBA(0, 0, 0, 0.5)
+
+	Equal(t, Contrast(black, white), 21.0)
+	Equal(t, Contrast(white, black), 21.0)
+	Equal(t, Contrast(white, white), 1.0)
+	Equal(t, Contrast(none, white), 1.0)
+	Equal(t, math.Round(Contrast(gray, white)*100)/100, 4.54)
+	Equal(t, Contrast(gray, white) >= ContrastAA, true)
+	Equal(t, math.Round(Contrast(half, white)*100)/100, 3.95)
+}
+
+func TestCheckContrast(t *testing.T) {
+
+	gray, _ := ParseHEX("#767676")
+	white, _ := ParseHEX("#fff")
+
+	Equal(t, CheckContrast(gray, white), ContrastLevels{Ratio: 4.54, AA: true, AALarge: true, AAALarge: true})
+}
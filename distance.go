@@ -0,0 +1,161 @@
+package colors
+
+import "math"
+
+// DistanceMetric is the method used to measure how different two colors are
+type DistanceMetric uint8
+
+// Distance metrics
+const (
+	// DistanceRGB is the euclidean distance between 8-bit sRGB channels
+	DistanceRGB DistanceMetric = iota
+
+	// DistanceOKLab is the euclidean distance in the OKLab color space, also known as deltaEOK
+	DistanceOKLab
+
+	// DistanceCIEDE2000 is the CIE deltaE 2000 difference between CIELAB colors
+	DistanceCIEDE2000
+)
+
+// Distance returns the distance between c and d using the metric, alpha is ignored
+func (m DistanceMetric) Distance(c, d Color) float64 {
+
+	c1 := c.ToRGB()
+	c2 := d.ToRGB()
+
+	return m.between(
+		m.point(float64(c1.R), float64(c1.G), float64(c1.B)),
+		m.point(float64(c2.R), float64(c2.G), float64(c2.B)),
+	)
+}
+
+// point converts sRGB channels in the range [0, 255] to the metric's color space
+func (m DistanceMetric) point(r, g, b float64) [3]float64 {
+
+	switch m {
+	case DistanceOKLab:
+		lab := linearRGBToOKLab(srgbToLinear(r/255), srgbToLinear(g/255), srgbToLinear(b/255))
+		return [3]float64{lab.L, lab.A, lab.B}
+	case DistanceCIEDE2000:
+		return rgbToCIELAB(r, g, b)
+	default:
+		return [3]float64{r, g, b}
+	}
+}
+
+// between returns the distance between two points in the metric's color space
+func (m DistanceMetric) between(p, q [3]float64) float64 {
+
+	if m == DistanceCIEDE2000 {
+		return ciede2000(p, q)
+	}
+
+	d0 := p[0] - q[0]
+	d1 := p[1] - q[1]
+	d2 := p[2] - q[2]
+
+	return math.Sqrt(d0*d0 + d1*d1 + d2*d2)
+}
+
+// rgbToCIELAB converts sRGB channels in the range [0, 255] to CIELAB relative to D65
+func rgbToCIELAB(r, g, b float64) [3]float64 {
+
+	xyz := srgbToXYZ.apply([3]float64{srgbToLinear(r / 255), srgbToLinear(g / 255), srgbToLinear(b / 255)})
+
+	x := xyz[0] / d65White[0]
+	y := xyz[1] / d65White[1]
+	z := xyz[2] / d65White[2]
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+
+	fx, fy, fz := f(x), f(y), f(z)
+
+	return [3]float64{116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)}
+}
+
+// ciede2000 returns the CIE deltaE 2000 difference between two CIELAB colors
+// http://www2.ece.rochester.edu/~gsharma/ciede2000/ciede2000noteCRNA.pdf
+func ciede2000(p, q [3]float64) float64 {
+
+	l1, a1, b1 := p[0], p[1], p[2]
+	l2, a2, b2 := q[0], q[1], q[2]
+
+	cBar := (math.Hypot(a1, b1) + math.Hypot(a2, b2)) / 2
+	c7 := math.Pow(cBar, 7)
+	g := 0.5 * (1 - math.Sqrt(c7/(c7+math.Pow(25, 7))))
+
+	a1p := a1 * (1 + g)
+	a2p := a2 * (1 + g)
+	c1p := math.Hypot(a1p, b1)
+	c2p := math.Hypot(a2p, b2)
+
+	hue := func(b, a float64) float64 {
+		if a == 0 && b == 0 {
+			return 0
+		}
+		h := math.Atan2(b, a) * 180 / math.Pi
+		if h < 0 {
+			h += 360
+		}
+		return h
+	}
+
+	h1p := hue(b1, a1p)
+	h2p := hue(b2, a2p)
+
+	dLp := l2 - l1
+	dCp := c2p - c1p
+
+	var dhp float64
+
+	if c1p*c2p != 0 {
+		dhp = h2p - h1p
+		if dhp > 180 {
+			dhp -= 360
+		} else if dhp < -180 {
+			dhp += 360
+		}
+	}
+
+	dHp := 2 * math.Sqrt(c1p*c2p) * math.Sin(dhp/2*math.Pi/180)
+
+	lBarp := (l1 + l2) / 2
+	cBarp := (c1p + c2p) / 2
+
+	hBarp := h1p + h2p
+
+	if c1p*c2p != 0 {
+		if math.Abs(h1p-h2p) > 180 {
+			if hBarp < 360 {
+				hBarp += 360
+			} else {
+				hBarp -= 360
+			}
+		}
+		hBarp /= 2
+	}
+
+	rad := math.Pi / 180
+	t := 1 - 0.17*math.Cos((hBarp-30)*rad) + 0.24*math.Cos(2*hBarp*rad) +
+		0.32*math.Cos((3*hBarp+6)*rad) - 0.20*math.Cos((4*hBarp-63)*rad)
+
+	dTheta := 30 * math.Exp(-math.Pow((hBarp-275)/25, 2))
+	cBarp7 := math.Pow(cBarp, 7)
+	rc := 2 * math.Sqrt(cBarp7/(cBarp7+math.Pow(25, 7)))
+	lBarp50 := (lBarp - 50) * (lBarp - 50)
+	sl := 1 + 0.015*lBarp50/math.Sqrt(20+lBarp50)
+	sc := 1 + 0.045*cBarp
+	sh := 1 + 0.015*cBarp*t
+	rt := -math.Sin(2*dTheta*rad) * rc
+
+	dl := dLp / sl
+	dc := dCp / sc
+	dh := dHp / sh
+
+	return math.Sqrt(dl*dl + dc*dc + dh*dh + rt*dc*dh)
+}
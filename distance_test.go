@@ -0,0 +1,29 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+
+	round := func(f float64) float64 {
+		return math.Round(f*10000) / 10000
+	}
+
+	// reference pairs from Sharma, Wu and Dalal's CIEDE2000 test data
+	Equal(t, round(ciede2000([3]float64{50, 2.6772, -79.7751}, [3]float64{50, 0, -82.7485})), 2.0425)
+	Equal(t, round(ciede2000([3]float64{50, -1, 2}, [3]float64{50, 0, 0})), 2.3669)
+	Equal(t, round(ciede2000([3]float64{2.0776, 0.0795, -1.135}, [3]float64{0.9033, -0.0636, -0.5514})), 0.9082)
+
+	black, _ := ParseHEX("#000")
+	white, _ := RGB(255, 255, 255)
+	red, _ := RGBA(255, 0, 0, 0.5)
+
+	Equal(t, DistanceRGB.Distance(black, black), 0.0)
+	Equal(t, round(DistanceRGB.Distance(black, white)), 441.673)
+	Equal(t, round(DistanceOKLab.Distance(black, white)), 1.0)
+	Equal(t, round(DistanceCIEDE2000.Distance(black, white)), 100.0)
+	Equal(t, DistanceOKLab.Distance(red, white) > 0, true)
+	Equal(t, DistanceCIEDE2000.Distance(red, white), DistanceCIEDE2000.Distance(white, red))
+}
@@ -0,0 +1,15 @@
+// Package colors parses, converts, formats and generates colors, from CSS strings and
+// terminal escape sequences to palette files and wide gamut color spaces.
+//
+// # Random sources
+//
+// The package supports Go 1.17, which predates math/rand/v2, so NewRandom takes a RandomSource
+// that a *math/rand.Rand satisfies. A math/rand/v2 generator, which names Intn IntN, can be
+// used through an adapter:
+//
+//	type randV2 struct{ *rand.Rand }
+//
+//	func (r randV2) Intn(n int) int { return r.IntN(n) }
+//
+//	random := colors.NewRandom(randV2{rand.New(rand.NewPCG(1, 2))}, colors.RandomOptions{})
+package colors
@@ -0,0 +1,416 @@
+package colors
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrDuplicateName is returned when two colors would be written with the same name
+	ErrDuplicateName = errors.New("colors: duplicate color name")
+)
+
+var (
+	jsIdentifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+)
+
+// nameWords splits a color name into lowercase words on any non alphanumeric
+// characters and lower to upper case transitions, e.g. "Brand.primaryDark" -> brand primary dark
+func nameWords(name string) []string {
+
+	var words []string
+	var word []rune
+	var prev rune
+
+	for _, r := range name {
+
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = word[:0]
+			}
+			prev = r
+			continue
+		}
+
+		if unicode.IsUpper(r) && unicode.IsLower(prev) && len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+
+		word = append(word, unicode.ToLower(r))
+		prev = r
+	}
+
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	return words
+}
+
+// kebabName returns the name as lowercase words joined by hyphens, e.g. brand-primary
+func kebabName(name string) string {
+	return strings.Join(nameWords(name), "-")
+}
+
+// snakeName returns the name as lowercase words joined by underscores, e.g. brand_primary,
+// dropping any non ASCII characters so that only a-z, 0-9 and _ are used
+func snakeName(name string) string {
+
+	var words []string
+
+	for _, word := range nameWords(name) {
+
+		word = strings.Map(func(r rune) rune {
+			if r > unicode.MaxASCII {
+				return -1
+			}
+			return r
+		}, word)
+
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+
+	s := strings.Join(words, "_")
+
+	if s == "" || unicode.IsDigit(rune(s[0])) {
+		s = "color_" + s
+	}
+
+	return s
+}
+
+// camelName returns the name as lower camel case, e.g. brandPrimary
+func camelName(name string) string {
+
+	words := nameWords(name)
+
+	for i := 1; i < len(words); i++ {
+		words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+	}
+
+	s := strings.Join(words, "")
+
+	if s == "" || unicode.IsDigit(rune(s[0])) {
+		s = "color" + s
+	}
+
+	return s
+}
+
+// scssName returns the name as a kebab case SCSS variable name, prefixed when it would otherwise
+// start with a digit, e.g. color-500
+func scssName(name string) string {
+
+	s := kebabName(name)
+
+	if s == "" || unicode.IsDigit(rune(s[0])) {
+		s = "color-" + s
+	}
+
+	return s
+}
+
+// uniqueNames returns the names of the colors converted by name, or ErrDuplicateName
+// when two colors convert to the same name, e.g. brand.primary and Brand Primary
+func uniqueNames(colors []NamedColor, name func(string) string) ([]string, error) {
+
+	names := make([]string, len(colors))
+	seen := make(map[string]string, len(colors))
+
+	for i, c := range colors {
+
+		n := name(c.Name)
+
+		if prev, ok := seen[n]; ok {
+			return nil, fmt.Errorf("%w: %q and %q are both written as %s", ErrDuplicateName, prev, c.Name, n)
+		}
+
+		seen[n] = c.Name
+		names[i] = n
+	}
+
+	return names, nil
+}
+
+// WriteCSSVariables writes the colors as CSS custom properties within a :root block,
+// e.g. --brand-primary: #ff0000;, formatting values using f. Translucent colors keep their
+// alpha whatever f's HexAlpha option. ErrDuplicateName is returned, without writing anything,
+// when two colors have the same name once converted to kebab case
+func WriteCSSVariables(w io.Writer, colors []NamedColor, f Formatter) error {
+
+	names, err := uniqueNames(colors, kebabName)
+	if err != nil {
+		return err
+	}
+
+	f.HexAlpha = true
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprint(bw, ":root {\n")
+
+	for i, c := range colors {
+		fmt.Fprintf(bw, "  --%s: %s;\n", names[i], f.ToString(c.Color))
+	}
+
+	fmt.Fprint(bw, "}\n")
+
+	return bw.Flush()
+}
+
+// WriteSCSS writes the colors as SCSS variables, e.g. $brand-primary: #ff0000;, formatting values
+// using f. When mapName is not empty a map of the same name referencing the variables is also written.
+// Names starting with a digit are prefixed, e.g. $color-500, and translucent colors keep their alpha
+// whatever f's HexAlpha option. ErrDuplicateName is returned, without writing anything, when two
+// colors have the same variable name
+func WriteSCSS(w io.Writer, colors []NamedColor, f Formatter, mapName string) error {
+
+	names, err := uniqueNames(colors, scssName)
+	if err != nil {
+		return err
+	}
+
+	f.HexAlpha = true
+	bw := bufio.NewWriter(w)
+
+	for i, c := range colors {
+		fmt.Fprintf(bw, "$%s: %s;\n", names[i], f.ToString(c.Color))
+	}
+
+	if mapName != "" {
+
+		fmt.Fprintf(bw, "\n$%s: (\n", scssName(mapName))
+
+		for i, c := range colors {
+			fmt.Fprintf(bw, "  \"%s\": $%s,\n", kebabName(c.Name), names[i])
+		}
+
+		fmt.Fprint(bw, ");\n")
+	}
+
+	return bw.Flush()
+}
+
+// tailwindNode is an ordered, nested object of Tailwind colors
+type tailwindNode struct {
+	keys     []string
+	values   map[string]string
+	children map[string]*tailwindNode
+}
+
+func newTailwindNode() *tailwindNode {
+	return &tailwindNode{values: make(map[string]string), children: make(map[string]*tailwindNode)}
+}
+
+// set sets the value at the path, a value sharing its path with a nested object being stored as DEFAULT.
+// A value already at the path is replaced, so paths must be unique
+func (n *tailwindNode) set(path []string, value string) {
+
+	key := path[0]
+	_, isValue := n.values[key]
+	child, isChild := n.children[key]
+
+	if !isValue && !isChild {
+		n.keys = append(n.keys, key)
+	}
+
+	if len(path) == 1 {
+
+		if isChild {
+			child.set([]string{"DEFAULT"}, value)
+			return
+		}
+
+		n.values[key] = value
+		return
+	}
+
+	if !isChild {
+
+		child = newTailwindNode()
+		n.children[key] = child
+
+		if isValue {
+			child.set([]string{"DEFAULT"}, n.values[key])
+			delete(n.values, key)
+		}
+	}
+
+	child.set(path[1:], value)
+}
+
+func (n *tailwindNode) writeJS(bw *bufio.Writer, indent string) {
+
+	for _, key := range n.keys {
+
+		k := key
+		if !jsIdentifierRegex.MatchString(k) {
+			k = "'" + k + "'"
+		}
+
+		if child, ok := n.children[key]; ok {
+			fmt.Fprintf(bw, "%s%s: {\n", indent, k)
+			child.writeJS(bw, indent+"  ")
+			fmt.Fprintf(bw, "%s},\n", indent)
+			continue
+		}
+
+		fmt.Fprintf(bw, "%s%s: '%s',\n", indent, k, n.values[key])
+	}
+}
+
+func (n *tailwindNode) writeJSON(buf *strings.Builder) {
+
+	buf.WriteByte('{')
+
+	for i, key := range n.keys {
+
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+
+		if child, ok := n.children[key]; ok {
+			child.writeJSON(buf)
+			continue
+		}
+
+		v, _ := json.Marshal(n.values[key])
+		buf.Write(v)
+	}
+
+	buf.WriteByte('}')
+}
+
+// tailwindPath returns the nested object keys of a color name, e.g. brand primary for brand.primary
+func tailwindPath(name string) []string {
+
+	var path []string
+
+	for _, seg := range strings.Split(name, ".") {
+		if k := kebabName(seg); k != "" {
+			path = append(path, k)
+		}
+	}
+
+	return path
+}
+
+// WriteTailwind writes the colors as a Tailwind CSS theme.colors config, formatting values using f.
+// Names are split on dots into nested objects, e.g. brand.primary, and written as a
+// module.exports JavaScript config or, when asJSON is true, as JSON. Translucent colors keep their
+// alpha whatever f's HexAlpha option. ErrDuplicateName is returned, without writing anything, when
+// two colors have the same path once converted to kebab case
+func WriteTailwind(w io.Writer, colors []NamedColor, f Formatter, asJSON bool) error {
+
+	named := make([]NamedColor, 0, len(colors))
+
+	for _, c := range colors {
+		if len(tailwindPath(c.Name)) > 0 {
+			named = append(named, c)
+		}
+	}
+
+	names, err := uniqueNames(named, func(name string) string {
+		return strings.Join(tailwindPath(name), ".")
+	})
+	if err != nil {
+		return err
+	}
+
+	f.HexAlpha = true
+	root := newTailwindNode()
+
+	for i, c := range named {
+		root.set(strings.Split(names[i], "."), f.ToString(c.Color))
+	}
+
+	theme := newTailwindNode()
+	theme.keys = []string{"theme"}
+	theme.children["theme"] = &tailwindNode{keys: []string{"colors"}, children: map[string]*tailwindNode{"colors": root}}
+
+	bw := bufio.NewWriter(w)
+
+	if asJSON {
+
+		var buf strings.Builder
+		theme.writeJSON(&buf)
+
+		var out bytes.Buffer
+
+		if err := json.Indent(&out, []byte(buf.String()), "", "  "); err != nil {
+			return err
+		}
+
+		out.WriteByte('\n')
+		_, _ = out.WriteTo(bw)
+
+		return bw.Flush()
+	}
+
+	fmt.Fprint(bw, "module.exports = {\n")
+	theme.writeJS(bw, "  ")
+	fmt.Fprint(bw, "}\n")
+
+	return bw.Flush()
+}
+
+// WriteAndroidXML writes the colors as an Android colors.xml resource file using #AARRGGBB values.
+// ErrDuplicateName is returned, without writing anything, when two colors have the same resource name
+func WriteAndroidXML(w io.Writer, colors []NamedColor) error {
+
+	names, err := uniqueNames(colors, snakeName)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprint(bw, "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>\n")
+
+	for i, c := range colors {
+		rgba := c.Color.ToRGBA()
+		fmt.Fprintf(bw, "    <color name=\"%s\">#%02X%02X%02X%02X</color>\n", names[i], clampUint8(rgba.A), rgba.R, rgba.G, rgba.B)
+	}
+
+	fmt.Fprint(bw, "</resources>\n")
+
+	return bw.Flush()
+}
+
+// WriteSwift writes the colors as a Swift UIColor extension of static constants for iOS.
+// ErrDuplicateName is returned, without writing anything, when two colors have the same constant name
+func WriteSwift(w io.Writer, colors []NamedColor) error {
+
+	names, err := uniqueNames(colors, camelName)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprint(bw, "import UIKit\n\nextension UIColor {\n")
+
+	for i, c := range colors {
+		rgba := c.Color.ToRGBA()
+		fmt.Fprintf(bw, "    static let %s = UIColor(red: %.4f, green: %.4f, blue: %.4f, alpha: %.4f)\n",
+			names[i], float64(rgba.R)/255, float64(rgba.G)/255, float64(rgba.B)/255, rgba.A)
+	}
+
+	fmt.Fprint(bw, "}\n")
+
+	return bw.Flush()
+}
@@ -0,0 +1,135 @@
+package colors
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func testNamedColors() []NamedColor {
+
+	red, _ := ParseHEX("#ff0000")
+	blue, _ := RGBA(0, 0, 255, 0.5)
+	gray, _ := RGB(136, 136, 136)
+
+	return []NamedColor{
+		{Name: "brand", Color: gray},
+		{Name: "brand.primary", Color: red},
+		{Name: "Brand.secondaryDark", Color: blue},
+		{Name: "500", Color: gray},
+	}
+}
+
+func TestWriteCSSVariables(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	Equal(t, WriteCSSVariables(&buf, testNamedColors(), Formatter{ShortHex: true}), nil)
+	Equal(t, buf.String(), ":root {\n  --brand: #888;\n  --brand-primary: #f00;\n  --brand-secondary-dark: #0000ff80;\n  --500: #888;\n}\n")
+
+	buf.Reset()
+	Equal(t, WriteCSSVariables(&buf, testNamedColors()[2:3], Formatter{Syntax: SyntaxOKLCH, OmitOpaqueAlpha: true, Precision: 1}), nil)
+	Equal(t, buf.String(), ":root {\n  --brand-secondary-dark: oklch(45.2% 0.3 264.1 / 0.5);\n}\n")
+}
+
+func TestWriteSCSS(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	Equal(t, WriteSCSS(&buf, testNamedColors()[1:3], Formatter{Syntax: SyntaxRGB, OmitOpaqueAlpha: true}, "Brand Colors"), nil)
+	Equal(t, buf.String(), "$brand-primary: rgb(255,0,0);\n$brand-secondary-dark: rgba(0,0,255,0.5);\n\n"+
+		"$brand-colors: (\n  \"brand-primary\": $brand-primary,\n  \"brand-secondary-dark\": $brand-secondary-dark,\n);\n")
+
+	buf.Reset()
+	Equal(t, WriteSCSS(&buf, testNamedColors()[1:], Formatter{}, "500s"), nil)
+	Equal(t, buf.String(), "$brand-primary: #ff0000;\n$brand-secondary-dark: #0000ff80;\n$color-500: #888888;\n\n"+
+		"$color-500s: (\n  \"brand-primary\": $brand-primary,\n  \"brand-secondary-dark\": $brand-secondary-dark,\n  \"500\": $color-500,\n);\n")
+
+	duplicates := append(testNamedColors(), NamedColor{Name: "Brand Primary", Color: &RGBColor{}})
+
+	buf.Reset()
+	err := WriteSCSS(&buf, duplicates, Formatter{}, "")
+	Equal(t, errors.Is(err, ErrDuplicateName), true)
+	Equal(t, err.Error(), `colors: duplicate color name: "brand.primary" and "Brand Primary" are both written as brand-primary`)
+	Equal(t, buf.Len(), 0)
+
+	Equal(t, errors.Is(WriteCSSVariables(&buf, duplicates, Formatter{}), ErrDuplicateName), true)
+}
+
+func TestWriteTailwind(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	Equal(t, WriteTailwind(&buf, testNamedColors(), Formatter{}, false), nil)
+	Equal(t, buf.String(), `module.exports = {
+  theme: {
+    colors: {
+      brand: {
+        DEFAULT: '#888888',
+        primary: '#ff0000',
+        'secondary-dark': '#0000ff80',
+      },
+      '500': '#888888',
+    },
+  },
+}
+`)
+
+	buf.Reset()
+	Equal(t, WriteTailwind(&buf, testNamedColors()[1:2], Formatter{}, true), nil)
+	Equal(t, buf.String(), `{
+  "theme": {
+    "colors": {
+      "brand": {
+        "primary": "#ff0000"
+      }
+    }
+  }
+}
+`)
+
+	duplicates := append(testNamedColors(), NamedColor{Name: "Brand.Primary", Color: &RGBColor{}})
+
+	buf.Reset()
+	err := WriteTailwind(&buf, duplicates, Formatter{}, false)
+	Equal(t, errors.Is(err, ErrDuplicateName), true)
+	Equal(t, err.Error(), `colors: duplicate color name: "brand.primary" and "Brand.Primary" are both written as brand.primary`)
+	Equal(t, buf.Len(), 0)
+}
+
+func TestWriteMobile(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	Equal(t, WriteAndroidXML(&buf, testNamedColors()[1:]), nil)
+	Equal(t, buf.String(), `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <color name="brand_primary">#FFFF0000</color>
+    <color name="brand_secondary_dark">#800000FF</color>
+    <color name="color_500">#FF888888</color>
+</resources>
+`)
+
+	buf.Reset()
+	Equal(t, WriteSwift(&buf, testNamedColors()[1:]), nil)
+	Equal(t, buf.String(), `import UIKit
+
+extension UIColor {
+    static let brandPrimary = UIColor(red: 1.0000, green: 0.0000, blue: 0.0000, alpha: 1.0000)
+    static let brandSecondaryDark = UIColor(red: 0.0000, green: 0.0000, blue: 1.0000, alpha: 0.5000)
+    static let color500 = UIColor(red: 0.5333, green: 0.5333, blue: 0.5333, alpha: 1.0000)
+}
+`)
+
+	duplicates := append(testNamedColors(), NamedColor{Name: "brand primary", Color: &RGBColor{}})
+
+	buf.Reset()
+	Equal(t, errors.Is(WriteAndroidXML(&buf, duplicates), ErrDuplicateName), true)
+	Equal(t, errors.Is(WriteSwift(&buf, duplicates), ErrDuplicateName), true)
+	Equal(t, buf.Len(), 0)
+
+	Equal(t, snakeName("Grün.500"), "grn_500")
+	Equal(t, snakeName("ß"), "color_")
+	Equal(t, WriteAndroidXML(&buf, []NamedColor{{Name: "grün", Color: &RGBColor{}}}), nil)
+	Equal(t, bytes.Contains(buf.Bytes(), []byte(`<color name="grn">`)), true)
+}
@@ -0,0 +1,336 @@
+package colors
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Any wraps a Color of unknown type so that it can be marshalled and
+// unmarshalled, determining the concrete type using Parse
+type Any struct {
+	Color
+}
+
+// decodeJSONString decodes a JSON string, reporting whether the value was null
+func decodeJSONString(data []byte) (s string, null bool, err error) {
+
+	if string(data) == "null" {
+		return "", true, nil
+	}
+
+	err = json.Unmarshal(data, &s)
+
+	return s, false, err
+}
+
+// scanString converts a database value to a string, reporting whether the value was NULL
+func scanString(src interface{}) (s string, null bool, err error) {
+
+	switch v := src.(type) {
+	case nil:
+		return "", true, nil
+	case string:
+		return v, false, nil
+	case []byte:
+		return string(v), false, nil
+	default:
+		return "", false, fmt.Errorf("colors: cannot scan %T into a Color", src)
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (c HEXColor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (c *HEXColor) UnmarshalText(text []byte) error {
+
+	hex, err := ParseHEX(string(text))
+	if err != nil {
+		return err
+	}
+
+	*c = *hex
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (c HEXColor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, a JSON null leaves the color unchanged
+func (c *HEXColor) UnmarshalJSON(data []byte) error {
+
+	s, null, err := decodeJSONString(data)
+	if err != nil || null {
+		return err
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner, NULL values are rejected; scan into a **HEXColor to allow them
+func (c *HEXColor) Scan(src interface{}) error {
+
+	s, null, err := scanString(src)
+	if err != nil {
+		return err
+	}
+
+	if null {
+		return ErrBadColor
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// Value implements driver.Valuer
+func (c HEXColor) Value() (driver.Value, error) {
+	return c.String(), nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (c RGBColor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (c *RGBColor) UnmarshalText(text []byte) error {
+
+	rgb, err := ParseRGB(string(text))
+	if err != nil {
+		return err
+	}
+
+	*c = *rgb
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (c RGBColor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, a JSON null leaves the color unchanged
+func (c *RGBColor) UnmarshalJSON(data []byte) error {
+
+	s, null, err := decodeJSONString(data)
+	if err != nil || null {
+		return err
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner, NULL values are rejected; scan into a **RGBColor to allow them
+func (c *RGBColor) Scan(src interface{}) error {
+
+	s, null, err := scanString(src)
+	if err != nil {
+		return err
+	}
+
+	if null {
+		return ErrBadColor
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// Value implements driver.Valuer
+func (c RGBColor) Value() (driver.Value, error) {
+	return c.String(), nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (c RGBAColor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (c *RGBAColor) UnmarshalText(text []byte) error {
+
+	rgba, err := ParseRGBA(string(text))
+	if err != nil {
+		return err
+	}
+
+	*c = *rgba
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (c RGBAColor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, a JSON null leaves the color unchanged
+func (c *RGBAColor) UnmarshalJSON(data []byte) error {
+
+	s, null, err := decodeJSONString(data)
+	if err != nil || null {
+		return err
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner, NULL values are rejected; scan into a **RGBAColor to allow them
+func (c *RGBAColor) Scan(src interface{}) error {
+
+	s, null, err := scanString(src)
+	if err != nil {
+		return err
+	}
+
+	if null {
+		return ErrBadColor
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// Value implements driver.Valuer
+func (c RGBAColor) Value() (driver.Value, error) {
+	return c.String(), nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (c SpaceColor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (c *SpaceColor) UnmarshalText(text []byte) error {
+
+	sc, err := ParseSpaceColor(string(text))
+	if err != nil {
+		return err
+	}
+
+	*c = *sc
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (c SpaceColor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, a JSON null leaves the color unchanged
+func (c *SpaceColor) UnmarshalJSON(data []byte) error {
+
+	s, null, err := decodeJSONString(data)
+	if err != nil || null {
+		return err
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner, NULL values are rejected; scan into a **SpaceColor to allow them
+func (c *SpaceColor) Scan(src interface{}) error {
+
+	s, null, err := scanString(src)
+	if err != nil {
+		return err
+	}
+
+	if null {
+		return ErrBadColor
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// Value implements driver.Valuer
+func (c SpaceColor) Value() (driver.Value, error) {
+	return c.String(), nil
+}
+
+// MarshalText implements encoding.TextMarshaler, a nil Color is marshalled as empty text
+func (a Any) MarshalText() ([]byte, error) {
+
+	if a.Color == nil {
+		return []byte{}, nil
+	}
+
+	return []byte(a.Color.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, empty text results in a nil Color
+func (a *Any) UnmarshalText(text []byte) error {
+
+	if len(text) == 0 {
+		a.Color = nil
+		return nil
+	}
+
+	c, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	a.Color = c
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, a nil Color is marshalled as null
+func (a Any) MarshalJSON() ([]byte, error) {
+
+	if a.Color == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(a.Color.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, null results in a nil Color
+func (a *Any) UnmarshalJSON(data []byte) error {
+
+	s, null, err := decodeJSONString(data)
+	if err != nil {
+		return err
+	}
+
+	if null {
+		a.Color = nil
+		return nil
+	}
+
+	return a.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner, NULL results in a nil Color
+func (a *Any) Scan(src interface{}) error {
+
+	s, null, err := scanString(src)
+	if err != nil {
+		return err
+	}
+
+	if null {
+		a.Color = nil
+		return nil
+	}
+
+	return a.UnmarshalText([]byte(s))
+}
+
+// Value implements driver.Valuer, a nil Color is stored as NULL
+func (a Any) Value() (driver.Value, error) {
+
+	if a.Color == nil {
+		return nil, nil
+	}
+
+	return a.Color.String(), nil
+}
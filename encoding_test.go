@@ -0,0 +1,92 @@
+package colors
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalling(t *testing.T) {
+
+	type payload struct {
+		HEX  *HEXColor  `json:"hex"`
+		RGB  RGBColor   `json:"rgb"`
+		RGBA *RGBAColor `json:"rgba"`
+		Any  Any        `json:"any"`
+	}
+
+	hex, _ := ParseHEX("#5f55f5")
+	rgba, _ := RGBA(95, 85, 245, 0.5)
+
+	b, err := json.Marshal(payload{HEX: hex, RGB: RGBColor{R: 1, G: 2, B: 3}, RGBA: rgba, Any: Any{Color: hex}})
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"hex":"#5f55f5","rgb":"rgb(1,2,3)","rgba":"rgba(95,85,245,0.5)","any":"#5f55f5"}`)
+
+	var p payload
+	err = json.Unmarshal([]byte(`{"hex":"#FFF","rgb":"rgb(4,5,6)","rgba":"rgba(1,2,3,0.25)","any":"rgb(7,8,9)"}`), &p)
+	Equal(t, err, nil)
+	Equal(t, p.HEX.String(), "#fff")
+	Equal(t, p.RGB.String(), "rgb(4,5,6)")
+	Equal(t, p.RGBA.String(), "rgba(1,2,3,0.25)")
+	Equal(t, reflect.TypeOf(p.Any.Color) == reflect.TypeOf(&RGBColor{}), true)
+	Equal(t, p.Any.String(), "rgb(7,8,9)")
+
+	p = payload{}
+	err = json.Unmarshal([]byte(`{"hex":null,"any":null}`), &p)
+	Equal(t, err, nil)
+	Equal(t, p.HEX, nil)
+	Equal(t, p.Any.Color, nil)
+
+	b, err = json.Marshal(p.Any)
+	Equal(t, err, nil)
+	Equal(t, string(b), "null")
+
+	err = json.Unmarshal([]byte(`{"hex":"garbage"}`), &p)
+	Equal(t, err, ErrBadColor)
+
+	err = json.Unmarshal([]byte(`{"rgb":12}`), &p)
+	NotEqual(t, err, nil)
+
+	var a Any
+	Equal(t, a.UnmarshalText([]byte("rgba(1,2,3,1)")), nil)
+	text, err := a.MarshalText()
+	Equal(t, err, nil)
+	Equal(t, string(text), "rgba(1,2,3,1)")
+}
+
+func TestSQL(t *testing.T) {
+
+	var hex HEXColor
+	Equal(t, hex.Scan("#abc"), nil)
+	Equal(t, hex.String(), "#abc")
+	Equal(t, hex.Scan([]byte("#aabbcc")), nil)
+	Equal(t, hex.String(), "#aabbcc")
+	Equal(t, hex.Scan(nil), ErrBadColor)
+	NotEqual(t, hex.Scan(42), nil)
+
+	v, err := hex.Value()
+	Equal(t, err, nil)
+	Equal(t, v, "#aabbcc")
+
+	var rgb RGBColor
+	Equal(t, rgb.Scan("rgb(1,2,3)"), nil)
+	v, err = rgb.Value()
+	Equal(t, err, nil)
+	Equal(t, v, "rgb(1,2,3)")
+
+	var rgba RGBAColor
+	Equal(t, rgba.Scan("rgba(1,2,3,0.5)"), nil)
+	v, err = rgba.Value()
+	Equal(t, err, nil)
+	Equal(t, v, "rgba(1,2,3,0.5)")
+
+	var a Any
+	Equal(t, a.Scan("#fff"), nil)
+	Equal(t, reflect.TypeOf(a.Color) == reflect.TypeOf(&HEXColor{}), true)
+	Equal(t, a.Scan(nil), nil)
+	Equal(t, a.Color, nil)
+
+	v, err = a.Value()
+	Equal(t, err, nil)
+	Equal(t, v, nil)
+}
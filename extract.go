@@ -0,0 +1,472 @@
+package colors
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+const (
+	extractAlphaThreshold = 128
+	extractWhiteLightness = 0.95
+	extractBlackLightness = 0.15
+	defaultKMeansRounds   = 16
+
+	// dominantClusters is the number of colors Dominant extracts before picking the heaviest
+	dominantClusters = 5
+)
+
+// ExtractAlgorithm is the algorithm used to group the pixels of an image into colors
+type ExtractAlgorithm uint8
+
+// Extraction algorithms
+const (
+	// MedianCut recursively splits the color space at the median of its widest channel
+	MedianCut ExtractAlgorithm = iota
+
+	// Octree merges the least populated branches of an RGB octree
+	Octree
+
+	// KMeans clusters colors in the OKLab perceptual color space
+	KMeans
+)
+
+// ExtractOptions contains the options used when extracting colors from an image
+type ExtractOptions struct {
+	// Algorithm is the algorithm used to group pixels, defaults to MedianCut
+	Algorithm ExtractAlgorithm
+
+	// MaxSamples is the maximum number of pixels sampled, larger images are
+	// downsampled evenly across both axes. 0 samples every pixel
+	MaxSamples int
+
+	// IgnoreTransparent skips pixels that are more than half transparent
+	IgnoreTransparent bool
+
+	// SkipWhite skips near-white pixels, determined by OKLab lightness
+	SkipWhite bool
+
+	// SkipBlack skips near-black pixels, determined by OKLab lightness
+	SkipBlack bool
+
+	// Rounds is the maximum number of KMeans iterations, defaults to 16
+	Rounds int
+}
+
+// WeightedColor is a Color extracted from an image along with its population weight
+type WeightedColor struct {
+	Color Color
+
+	// Weight is the share of sampled pixels represented by the Color, in the range (0, 1]
+	Weight float64
+}
+
+// extractBucket is a distinct sampled color and the number of times it was seen
+type extractBucket struct {
+	r, g, b uint8
+	n       int
+}
+
+// Extract returns up to n colors that best represent the image, ordered by weight descending.
+// nil is returned when n < 1 or no pixels remain after applying the options
+func Extract(img image.Image, n int, opts ExtractOptions) []WeightedColor {
+
+	if n < 1 {
+		return nil
+	}
+
+	buckets, total := extractSamples(img, opts)
+
+	if total == 0 {
+		return nil
+	}
+
+	var results []WeightedColor
+
+	switch opts.Algorithm {
+	case Octree:
+		results = extractOctree(buckets, n)
+	case KMeans:
+		results = extractKMeans(buckets, n, opts.Rounds)
+	default:
+		results = extractMedianCut(buckets, n)
+	}
+
+	for i := range results {
+		results[i].Weight /= float64(total)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Weight > results[j].Weight
+	})
+
+	return results
+}
+
+// Dominant returns the color covering the largest part of the image, the heaviest of a few
+// extracted colors rather than the mean of every pixel, or nil if no pixels remain after
+// applying the options
+func Dominant(img image.Image, opts ExtractOptions) Color {
+
+	results := Extract(img, dominantClusters, opts)
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	return results[0].Color
+}
+
+// extractSamples samples the image according to opts and returns the
+// distinct colors seen along with the total number of pixels sampled
+func extractSamples(img image.Image, opts ExtractOptions) ([]extractBucket, int) {
+
+	bounds := img.Bounds()
+	step := 1
+
+	if opts.MaxSamples > 0 {
+		if pixels := bounds.Dx() * bounds.Dy(); pixels > opts.MaxSamples {
+			step = int(math.Ceil(math.Sqrt(float64(pixels) / float64(opts.MaxSamples))))
+		}
+	}
+
+	index := make(map[[3]uint8]int)
+	var buckets []extractBucket
+	var total int
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+
+			px := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+
+			if opts.IgnoreTransparent && px.A < extractAlphaThreshold {
+				continue
+			}
+
+			key := [3]uint8{px.R, px.G, px.B}
+
+			if i, ok := index[key]; ok {
+				buckets[i].n++
+				total++
+				continue
+			}
+
+			if opts.SkipWhite || opts.SkipBlack {
+				l := rgbToOKLab(px.R, px.G, px.B).L
+
+				if (opts.SkipWhite && l >= extractWhiteLightness) || (opts.SkipBlack && l <= extractBlackLightness) {
+					continue
+				}
+			}
+
+			index[key] = len(buckets)
+			buckets = append(buckets, extractBucket{r: px.R, g: px.G, b: px.B, n: 1})
+			total++
+		}
+	}
+
+	return buckets, total
+}
+
+// extractMean returns the population weighted mean color of the buckets and their combined population
+func extractMean(buckets []extractBucket) WeightedColor {
+
+	var r, g, b, n float64
+
+	for _, bk := range buckets {
+		w := float64(bk.n)
+		r += float64(bk.r) * w
+		g += float64(bk.g) * w
+		b += float64(bk.b) * w
+		n += w
+	}
+
+	return WeightedColor{
+		Color:  &RGBColor{R: uint8(r/n + .5), G: uint8(g/n + .5), B: uint8(b/n + .5)},
+		Weight: n,
+	}
+}
+
+// medianCutBox is a region of color space holding a subset of the sampled colors
+type medianCutBox struct {
+	buckets []extractBucket
+	channel int
+	spread  int
+	n       int
+}
+
+func newMedianCutBox(buckets []extractBucket) medianCutBox {
+
+	box := medianCutBox{buckets: buckets}
+	lo := [3]int{255, 255, 255}
+	hi := [3]int{}
+
+	for _, bk := range buckets {
+
+		box.n += bk.n
+
+		for i, v := range [3]uint8{bk.r, bk.g, bk.b} {
+			if int(v) < lo[i] {
+				lo[i] = int(v)
+			}
+			if int(v) > hi[i] {
+				hi[i] = int(v)
+			}
+		}
+	}
+
+	for i := range lo {
+		if hi[i]-lo[i] > box.spread {
+			box.spread = hi[i] - lo[i]
+			box.channel = i
+		}
+	}
+
+	return box
+}
+
+func (bk extractBucket) channel(i int) uint8 {
+	switch i {
+	case 0:
+		return bk.r
+	case 1:
+		return bk.g
+	default:
+		return bk.b
+	}
+}
+
+func extractMedianCut(buckets []extractBucket, n int) []WeightedColor {
+
+	boxes := []medianCutBox{newMedianCutBox(buckets)}
+
+	for len(boxes) < n {
+
+		// split the box whose population and spread make it the least representative
+		split := -1
+		var score int
+
+		for i, box := range boxes {
+			if len(box.buckets) > 1 && box.spread*box.n > score {
+				score = box.spread * box.n
+				split = i
+			}
+		}
+
+		if split == -1 {
+			break
+		}
+
+		box := boxes[split]
+		ch := box.channel
+
+		sort.Slice(box.buckets, func(i, j int) bool {
+			return box.buckets[i].channel(ch) < box.buckets[j].channel(ch)
+		})
+
+		// find the weighted median, keeping at least one bucket on each side
+		var seen, mid int
+
+		for mid = 0; mid < len(box.buckets)-1; mid++ {
+			seen += box.buckets[mid].n
+			if seen*2 >= box.n {
+				break
+			}
+		}
+
+		boxes[split] = newMedianCutBox(box.buckets[:mid+1])
+		boxes = append(boxes, newMedianCutBox(box.buckets[mid+1:]))
+	}
+
+	results := make([]WeightedColor, len(boxes))
+
+	for i, box := range boxes {
+		results[i] = extractMean(box.buckets)
+	}
+
+	return results
+}
+
+// octreeNode is a node within an RGB octree, each level splitting on the next most significant bit
+type octreeNode struct {
+	children [8]*octreeNode
+	buckets  []extractBucket
+	leaf     bool
+}
+
+func extractOctree(buckets []extractBucket, n int) []WeightedColor {
+
+	root := &octreeNode{}
+	levels := [8][]*octreeNode{{root}}
+	var leaves int
+
+	for _, bk := range buckets {
+
+		node := root
+
+		for depth := 0; depth < 8; depth++ {
+
+			shift := 7 - depth
+			idx := (bk.r>>shift)&1<<2 | (bk.g>>shift)&1<<1 | (bk.b>>shift)&1
+			child := node.children[idx]
+
+			if child == nil {
+				child = &octreeNode{}
+				node.children[idx] = child
+
+				if depth == 7 {
+					child.leaf = true
+					leaves++
+				} else {
+					levels[depth+1] = append(levels[depth+1], child)
+				}
+			}
+
+			node = child
+		}
+
+		node.buckets = append(node.buckets, bk)
+	}
+
+	// reduce the deepest, least populated nodes first; by the time a level is
+	// reduced every node below it has already been merged into a leaf
+	for depth := 7; depth >= 0 && leaves > n; depth-- {
+
+		nodes := levels[depth]
+		counts := make(map[*octreeNode]int, len(nodes))
+
+		for _, node := range nodes {
+			for _, child := range node.children {
+				if child != nil {
+					for _, bk := range child.buckets {
+						counts[node] += bk.n
+					}
+				}
+			}
+		}
+
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return counts[nodes[i]] < counts[nodes[j]]
+		})
+
+		for _, node := range nodes {
+
+			if leaves <= n {
+				break
+			}
+
+			for i, child := range node.children {
+				if child != nil {
+					node.buckets = append(node.buckets, child.buckets...)
+					node.children[i] = nil
+					leaves--
+				}
+			}
+
+			node.leaf = true
+			leaves++
+		}
+	}
+
+	var results []WeightedColor
+	var walk func(node *octreeNode)
+
+	walk = func(node *octreeNode) {
+
+		if node.leaf {
+			results = append(results, extractMean(node.buckets))
+			return
+		}
+
+		for _, child := range node.children {
+			if child != nil {
+				walk(child)
+			}
+		}
+	}
+	walk(root)
+
+	return results
+}
+
+func extractKMeans(buckets []extractBucket, n int, rounds int) []WeightedColor {
+
+	if rounds <= 0 {
+		rounds = defaultKMeansRounds
+	}
+
+	points := make([]oklab, len(buckets))
+
+	for i, bk := range buckets {
+		points[i] = rgbToOKLab(bk.r, bk.g, bk.b)
+	}
+
+	// seed the centroids deterministically from a median cut of the same samples
+	seeds := extractMedianCut(append([]extractBucket(nil), buckets...), n)
+	centroids := make([]oklab, len(seeds))
+
+	for i, s := range seeds {
+		rgb := s.Color.ToRGB()
+		centroids[i] = rgbToOKLab(rgb.R, rgb.G, rgb.B)
+	}
+
+	assigned := make([]int, len(points))
+	weights := make([]float64, len(centroids))
+
+	for round := 0; round < rounds; round++ {
+
+		changed := round == 0
+
+		for i, p := range points {
+
+			best, bestDist := 0, math.Inf(1)
+
+			for j, c := range centroids {
+				if d := p.distance(c); d < bestDist {
+					best, bestDist = j, d
+				}
+			}
+
+			if assigned[i] != best {
+				assigned[i] = best
+				changed = true
+			}
+		}
+
+		if !changed {
+			break
+		}
+
+		sums := make([]oklab, len(centroids))
+
+		for j := range weights {
+			weights[j] = 0
+		}
+
+		for i, p := range points {
+			w := float64(buckets[i].n)
+			j := assigned[i]
+			sums[j].L += p.L * w
+			sums[j].A += p.A * w
+			sums[j].B += p.B * w
+			weights[j] += w
+		}
+
+		for j, s := range sums {
+			if weights[j] > 0 {
+				centroids[j] = oklab{L: s.L / weights[j], A: s.A / weights[j], B: s.B / weights[j]}
+			}
+		}
+	}
+
+	var results []WeightedColor
+
+	for j, c := range centroids {
+		if weights[j] > 0 {
+			results = append(results, WeightedColor{Color: c.toRGB(), Weight: weights[j]})
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,84 @@
+package colors
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func newStripedImage(stripes ...interface{}) *image.NRGBA {
+
+	var width int
+
+	for i := 1; i < len(stripes); i += 2 {
+		width += stripes[i].(int)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, 4))
+	x := 0
+
+	for i := 0; i < len(stripes); i += 2 {
+		for n := 0; n < stripes[i+1].(int); n, x = n+1, x+1 {
+			for y := 0; y < 4; y++ {
+				img.SetNRGBA(x, y, stripes[i].(color.NRGBA))
+			}
+		}
+	}
+
+	return img
+}
+
+func TestExtract(t *testing.T) {
+
+	red := color.NRGBA{R: 220, G: 20, B: 60, A: 255}
+	blue := color.NRGBA{R: 30, G: 60, B: 200, A: 255}
+	green := color.NRGBA{R: 40, G: 180, B: 80, A: 255}
+
+	img := newStripedImage(red, 50, blue, 30, green, 20)
+
+	for _, algo := range []ExtractAlgorithm{MedianCut, Octree, KMeans} {
+
+		results := Extract(img, 3, ExtractOptions{Algorithm: algo})
+		Equal(t, len(results), 3)
+		Equal(t, results[0].Color.String(), "rgb(220,20,60)")
+		Equal(t, results[0].Weight, 0.5)
+		Equal(t, results[1].Color.String(), "rgb(30,60,200)")
+		Equal(t, results[1].Weight, 0.3)
+		Equal(t, results[2].Color.String(), "rgb(40,180,80)")
+
+		results = Extract(img, 5, ExtractOptions{Algorithm: algo, MaxSamples: 100})
+		Equal(t, len(results), 3)
+
+		results = Extract(img, 1, ExtractOptions{Algorithm: algo})
+		Equal(t, len(results), 1)
+		Equal(t, results[0].Weight, 1.0)
+
+		Equal(t, Dominant(img, ExtractOptions{Algorithm: algo}).String(), "rgb(220,20,60)")
+	}
+
+	Equal(t, Extract(img, 0, ExtractOptions{}), nil)
+	Equal(t, Dominant(img, ExtractOptions{}).String(), "rgb(220,20,60)")
+}
+
+func TestExtractOptions(t *testing.T) {
+
+	red := color.NRGBA{R: 220, G: 20, B: 60, A: 255}
+	white := color.NRGBA{R: 250, G: 250, B: 250, A: 255}
+	black := color.NRGBA{R: 5, G: 5, B: 5, A: 255}
+	faint := color.NRGBA{R: 0, G: 200, B: 0, A: 10}
+
+	img := newStripedImage(white, 40, black, 30, faint, 20, red, 10)
+
+	results := Extract(img, 4, ExtractOptions{})
+	Equal(t, len(results), 4)
+
+	results = Extract(img, 4, ExtractOptions{IgnoreTransparent: true, SkipWhite: true, SkipBlack: true})
+	Equal(t, len(results), 1)
+	Equal(t, results[0].Color.String(), "rgb(220,20,60)")
+
+	results = Extract(img, 4, ExtractOptions{SkipWhite: true})
+	Equal(t, len(results), 3)
+	Equal(t, results[0].Color.String(), "rgb(5,5,5)")
+
+	Equal(t, Dominant(newStripedImage(faint, 10), ExtractOptions{IgnoreTransparent: true}), nil)
+}
@@ -0,0 +1,82 @@
+package colors
+
+import (
+	"image"
+	"image/color"
+	"strings"
+)
+
+const (
+	glyphWidth  = 5
+	glyphHeight = 7
+
+	// glyphAdvance is the horizontal distance between glyphs, including one column of spacing
+	glyphAdvance = glyphWidth + 1
+
+	// glyphLineHeight is the vertical distance between lines of text, including two rows of spacing
+	glyphLineHeight = glyphHeight + 2
+)
+
+// glyphs is a minimal 5x7 bitmap font of the characters used by swatch labels,
+// any other character being drawn as a space
+var glyphs = map[rune][glyphHeight]string{
+	'0': {" ### ", "#   #", "#  ##", "# # #", "##  #", "#   #", " ### "},
+	'1': {"  #  ", " ##  ", "  #  ", "  #  ", "  #  ", "  #  ", " ### "},
+	'2': {" ### ", "#   #", "    #", "   # ", "  #  ", " #   ", "#####"},
+	'3': {"#####", "   # ", "  #  ", "   # ", "    #", "#   #", " ### "},
+	'4': {"   # ", "  ## ", " # # ", "#  # ", "#####", "   # ", "   # "},
+	'5': {"#####", "#    ", "#### ", "    #", "    #", "#   #", " ### "},
+	'6': {"  ## ", " #   ", "#    ", "#### ", "#   #", "#   #", " ### "},
+	'7': {"#####", "    #", "   # ", "  #  ", " #   ", " #   ", " #   "},
+	'8': {" ### ", "#   #", "#   #", " ### ", "#   #", "#   #", " ### "},
+	'9': {" ### ", "#   #", "#   #", " ####", "    #", "   # ", " ##  "},
+	'a': {"     ", "     ", " ### ", "    #", " ####", "#   #", " ####"},
+	'b': {"#    ", "#    ", "# ## ", "##  #", "#   #", "#   #", "#### "},
+	'c': {"     ", "     ", " ### ", "#    ", "#    ", "#   #", " ### "},
+	'd': {"    #", "    #", " ## #", "#  ##", "#   #", "#   #", " ####"},
+	'e': {"     ", "     ", " ### ", "#   #", "#####", "#    ", " ### "},
+	'f': {"  ## ", " #  #", " #   ", "###  ", " #   ", " #   ", " #   "},
+	'#': {" # # ", " # # ", "#####", " # # ", "#####", " # # ", " # # "},
+	'.': {"     ", "     ", "     ", "     ", "     ", " ##  ", " ##  "},
+	':': {"     ", " ##  ", " ##  ", "     ", " ##  ", " ##  ", "     "},
+}
+
+// textWidth returns the width in pixels of text drawn at the given scale
+func textWidth(text string, scale int) int {
+
+	n := len([]rune(text))
+	if n == 0 {
+		return 0
+	}
+
+	return (n*glyphAdvance - 1) * scale
+}
+
+// drawText draws lowercased text with its top left corner at x, y, each font pixel being scale pixels square
+func drawText(img *image.NRGBA, x, y int, text string, scale int, c color.NRGBA) {
+
+	for _, r := range strings.ToLower(text) {
+
+		glyph := glyphs[r]
+
+		for row, line := range glyph {
+			for col := 0; col < len(line); col++ {
+
+				if line[col] != '#' {
+					continue
+				}
+
+				for dy := 0; dy < scale; dy++ {
+					for dx := 0; dx < scale; dx++ {
+						px, py := x+(col*scale)+dx, y+(row*scale)+dy
+						if (image.Point{X: px, Y: py}).In(img.Rect) {
+							img.SetNRGBA(px, py, c)
+						}
+					}
+				}
+			}
+		}
+
+		x += glyphAdvance * scale
+	}
+}
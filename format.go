@@ -0,0 +1,149 @@
+package colors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// formatFloat formats v with prec decimals, removing trailing zeros unless fixed
+func formatFloat(v float64, prec int, fixed bool) string {
+
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+
+	if !fixed && strings.IndexByte(s, '.') != -1 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+
+	if s == "-0" {
+		s = "0"
+	}
+
+	return s
+}
+
+// formatAlpha formats the alpha value as formatFloat does, or as %g when prec < 0
+func formatAlpha(a float64, prec int, fixed bool) string {
+
+	if prec < 0 {
+		return strconv.FormatFloat(a, 'g', -1, 64)
+	}
+
+	return formatFloat(a, prec, fixed)
+}
+
+// formatHex returns the hex representation of the RGBColor, using the
+// 3 digit short form when requested and no information would be lost
+func formatHex(c *RGBColor, upper, short bool) string {
+
+	var s string
+
+	if short && c.R%hexToRGBFactor == 0 && c.G%hexToRGBFactor == 0 && c.B%hexToRGBFactor == 0 {
+		s = fmt.Sprintf(hexShortFormat, c.R/hexToRGBFactor, c.G/hexToRGBFactor, c.B/hexToRGBFactor)
+	} else {
+		s = fmt.Sprintf(hexFormat, c.R, c.G, c.B)
+	}
+
+	if upper {
+		return strings.ToUpper(s)
+	}
+
+	return s
+}
+
+// formatDebug returns a representation of the Color showing every channel and the alpha
+func formatDebug(c Color) string {
+
+	rgba := c.ToRGBA()
+
+	return fmt.Sprintf("%T{%s R:%d G:%d B:%d A:%g}", c, c.String(), rgba.R, rgba.G, rgba.B, rgba.A)
+}
+
+// formatColor implements fmt.Formatter for all Color types
+func formatColor(f fmt.State, verb rune, c Color) {
+
+	formatter := Formatter{OmitOpaqueAlpha: true}
+
+	if prec, ok := f.Precision(); ok {
+
+		// a fmt precision of 0 means whole numbers rather than the Formatter's default
+		if prec == 0 {
+			prec = PrecisionWhole
+		}
+
+		formatter.Precision = prec
+		formatter.AlphaPrecision = prec
+		formatter.fixed = true
+	}
+
+	var s string
+
+	switch verb {
+	case 's':
+		s = c.String()
+	case 'v':
+		if f.Flag('+') {
+			s = formatDebug(c)
+		} else {
+			s = c.String()
+		}
+	case 'q':
+		s = strconv.Quote(c.String())
+	case 'x', 'X':
+		formatter.UpperHex = verb == 'X'
+		formatter.ShortHex = f.Flag('#')
+		s = formatter.ToString(c)
+	case 'r':
+		formatter.Syntax = SyntaxRGB
+		s = formatter.ToString(c)
+	case 'h':
+		formatter.Syntax = SyntaxHSL
+		s = formatter.ToString(c)
+	case 'o':
+		formatter.Syntax = SyntaxOKLCH
+		s = formatter.ToString(c)
+	default:
+		fmt.Fprintf(f, "%%!%c(%T=%s)", verb, c, c.String())
+		return
+	}
+
+	if width, ok := f.Width(); ok && width > len(s) {
+		if f.Flag('-') {
+			s += strings.Repeat(" ", width-len(s))
+		} else {
+			s = strings.Repeat(" ", width-len(s)) + s
+		}
+	}
+
+	_, _ = f.Write([]byte(s))
+}
+
+// Format implements fmt.Formatter, supporting the following verbs:
+//
+//	%s, %v  the String representation
+//	%+v     a debug representation showing every channel and the alpha
+//	%q      the quoted String representation
+//	%x, %X  lower or uppercase hex, with the # flag using short hex when lossless
+//	%r      rgb(), or rgba() when translucent
+//	%h      hsl(), or hsla() when translucent
+//	%o      oklch()
+//
+// A precision, e.g. %.1h, fixes the number of decimals of fractional values
+func (c *HEXColor) Format(f fmt.State, verb rune) {
+	formatColor(f, verb, c)
+}
+
+// Format implements fmt.Formatter, see HEXColor.Format for the supported verbs
+func (c *RGBColor) Format(f fmt.State, verb rune) {
+	formatColor(f, verb, c)
+}
+
+// Format implements fmt.Formatter, see HEXColor.Format for the supported verbs
+func (c *RGBAColor) Format(f fmt.State, verb rune) {
+	formatColor(f, verb, c)
+}
+
+// Format implements fmt.Formatter, see HEXColor.Format for the supported verbs
+func (c *SpaceColor) Format(f fmt.State, verb rune) {
+	formatColor(f, verb, c)
+}
@@ -0,0 +1,42 @@
+package colors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+
+	hex, _ := ParseHEX("#5f55f5")
+	rgb, _ := RGB(255, 0, 0)
+	rgba, _ := RGBA(95, 85, 245, 0.5)
+
+	Equal(t, fmt.Sprintf("%s", hex), "#5f55f5")
+	Equal(t, fmt.Sprintf("%v", rgba), "rgba(95,85,245,0.5)")
+	Equal(t, fmt.Sprintf("%+v", hex), "*colors.HEXColor{#5f55f5 R:95 G:85 B:245 A:1}")
+	Equal(t, fmt.Sprintf("%+v", rgba), "*colors.RGBAColor{rgba(95,85,245,0.5) R:95 G:85 B:245 A:0.5}")
+	Equal(t, fmt.Sprintf("%q", rgb), `"rgb(255,0,0)"`)
+
+	Equal(t, fmt.Sprintf("%x", rgb), "#ff0000")
+	Equal(t, fmt.Sprintf("%#x", rgb), "#f00")
+	Equal(t, fmt.Sprintf("%#X", rgb), "#F00")
+	Equal(t, fmt.Sprintf("%#x", hex), "#5f55f5")
+	Equal(t, fmt.Sprintf("%X", rgba), "#5F55F5")
+
+	Equal(t, fmt.Sprintf("%r", hex), "rgb(95,85,245)")
+	Equal(t, fmt.Sprintf("%r", rgba), "rgba(95,85,245,0.5)")
+	Equal(t, fmt.Sprintf("%.2r", rgba), "rgba(95,85,245,0.50)")
+
+	Equal(t, fmt.Sprintf("%h", rgb), "hsl(0,100%,50%)")
+	Equal(t, fmt.Sprintf("%h", hex), "hsl(243.75,88.89%,64.71%)")
+	Equal(t, fmt.Sprintf("%.1h", rgba), "hsla(243.8,88.9%,64.7%,0.5)")
+
+	Equal(t, fmt.Sprintf("%o", rgb), "oklch(62.8% 0.2577 29.23)")
+	Equal(t, fmt.Sprintf("%.1o", rgba), "oklch(55.8% 0.2 278.7 / 0.5)")
+
+	black, _ := RGB(0, 0, 0)
+	Equal(t, fmt.Sprintf("%o", black), "oklch(0% 0 0)")
+
+	Equal(t, fmt.Sprintf("%10x|%-10x|", rgb, rgb), "   #ff0000|#ff0000   |")
+	Equal(t, fmt.Sprintf("%d", rgb), "%!d(*colors.RGBColor=rgb(255,0,0))")
+}
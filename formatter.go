@@ -0,0 +1,202 @@
+package colors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Syntax is the notation used when formatting a Color as a string
+type Syntax uint8
+
+// Syntaxes
+const (
+	// SyntaxHEX formats colors as #rrggbb
+	SyntaxHEX Syntax = iota
+
+	// SyntaxRGB formats colors as rgb() or rgba()
+	SyntaxRGB
+
+	// SyntaxHSL formats colors as hsl() or hsla()
+	SyntaxHSL
+
+	// SyntaxOKLCH formats colors as oklch()
+	SyntaxOKLCH
+)
+
+// Precisions of a Formatter, which otherwise gives the maximum number of decimals
+const (
+	// PrecisionDefault uses 2 decimals for fractional channels, or 4 for OKLCH chroma,
+	// and the shortest exact representation for the alpha, as String does
+	PrecisionDefault = 0
+
+	// PrecisionWhole rounds to whole numbers, as a precision of 0 decimals would
+	PrecisionWhole = -1
+)
+
+// Formatter formats Colors as strings according to its options,
+// the zero value formats colors as lowercase 6 digit hex
+type Formatter struct {
+	// Syntax is the notation used
+	Syntax Syntax
+
+	// Modern uses the CSS Color 4 space separated syntax, e.g. rgb(95 85 245 / 0.5),
+	// rather than the legacy comma separated syntax, e.g. rgba(95,85,245,0.5).
+	// oklch() only has a modern syntax
+	Modern bool
+
+	// Percent formats rgb() channels as percentages rather than 0-255
+	Percent bool
+
+	// Precision is the maximum number of decimals of fractional channels, trailing zeros
+	// being removed, PrecisionDefault or PrecisionWhole. Other negative values are PrecisionWhole
+	Precision int
+
+	// AlphaPrecision is the maximum number of decimals of the alpha, trailing zeros being
+	// removed, PrecisionDefault or PrecisionWhole. Other negative values are PrecisionWhole
+	AlphaPrecision int
+
+	// ShortHex uses the 3 digit hex form when no information would be lost
+	ShortHex bool
+
+	// UpperHex uses uppercase hex digits
+	UpperHex bool
+
+	// HexAlpha appends the alpha as a 4th hex digit pair to translucent colors,
+	// otherwise SyntaxHEX drops the alpha as ToHEX does
+	HexAlpha bool
+
+	// OmitOpaqueAlpha leaves out the alpha when it is 1, e.g. rgb() rather than rgba()
+	OmitOpaqueAlpha bool
+
+	// fixed always outputs exactly Precision and AlphaPrecision decimals, used by fmt verbs
+	fixed bool
+}
+
+// ToString returns the string representation of the Color according to the Formatter's options
+func (f Formatter) ToString(c Color) string {
+
+	rgba := c.ToRGBA()
+
+	switch f.Syntax {
+	case SyntaxRGB:
+		return f.rgb(rgba)
+	case SyntaxHSL:
+		return f.hsl(rgba)
+	case SyntaxOKLCH:
+		return f.oklch(rgba)
+	default:
+		return f.hex(rgba)
+	}
+}
+
+// number formats a fractional channel, def being the default maximum number of decimals
+func (f Formatter) number(v float64, def int) string {
+
+	prec := f.Precision
+
+	if prec == PrecisionDefault {
+		prec = def
+	} else if prec < 0 {
+		prec = 0
+	}
+
+	return formatFloat(v, prec, f.fixed)
+}
+
+// alpha formats the alpha channel
+func (f Formatter) alpha(a float64) string {
+
+	prec := f.AlphaPrecision
+
+	if prec == PrecisionDefault {
+		prec = -1
+	} else if prec < 0 {
+		prec = 0
+	}
+
+	return formatAlpha(a, prec, f.fixed)
+}
+
+// function formats a color function such as rgb(), adding the alpha when required
+func (f Formatter) function(name string, a float64, vals ...string) string {
+
+	withAlpha := a != 1 || !f.OmitOpaqueAlpha
+
+	if f.Modern || name == "oklch" {
+
+		s := name + "(" + strings.Join(vals, " ")
+
+		if withAlpha {
+			s += " / " + f.alpha(a)
+		}
+
+		return s + ")"
+	}
+
+	if withAlpha {
+		return name + "a(" + strings.Join(vals, ",") + "," + f.alpha(a) + ")"
+	}
+
+	return name + "(" + strings.Join(vals, ",") + ")"
+}
+
+func (f Formatter) hex(c *RGBAColor) string {
+
+	a := clampUint8(c.A)
+	withAlpha := f.HexAlpha && c.A != 1
+
+	// the short form must keep the alpha lossless as well
+	short := f.ShortHex && (!withAlpha || a%hexToRGBFactor == 0)
+	s := formatHex(&RGBColor{R: c.R, G: c.G, B: c.B}, false, short)
+
+	if withAlpha {
+		if len(s) == 4 {
+			s += fmt.Sprintf("%1x", a/hexToRGBFactor)
+		} else {
+			s += fmt.Sprintf("%02x", a)
+		}
+	}
+
+	if f.UpperHex {
+		return strings.ToUpper(s)
+	}
+
+	return s
+}
+
+func (f Formatter) rgb(c *RGBAColor) string {
+
+	if f.Percent {
+		return f.function("rgb", c.A,
+			f.number(float64(c.R)/255*100, 2)+"%",
+			f.number(float64(c.G)/255*100, 2)+"%",
+			f.number(float64(c.B)/255*100, 2)+"%",
+		)
+	}
+
+	return f.function("rgb", c.A,
+		strconv.Itoa(int(c.R)),
+		strconv.Itoa(int(c.G)),
+		strconv.Itoa(int(c.B)),
+	)
+}
+
+func (f Formatter) hsl(c *RGBAColor) string {
+
+	h, s, l := rgbToHSL(c.R, c.G, c.B)
+
+	return f.function("hsl", c.A, f.number(h, 2), f.number(s*100, 2)+"%", f.number(l*100, 2)+"%")
+}
+
+func (f Formatter) oklch(c *RGBAColor) string {
+
+	l, ch, h := rgbToOKLab(c.R, c.G, c.B).lch()
+
+	// hue is powerless for achromatic colors
+	if ch < 1e-4 {
+		ch, h = 0, 0
+	}
+
+	return f.function("oklch", c.A, f.number(l*100, 2)+"%", f.number(ch, 4), f.number(h, 2))
+}
@@ -0,0 +1,38 @@
+package colors
+
+import "testing"
+
+func TestFormatter(t *testing.T) {
+
+	hex, _ := ParseHEX("#ff0000")
+	rgba, _ := RGBA(95, 85, 245, 0.534556634531)
+	gray, _ := RGBA(136, 136, 136, 0.6)
+
+	Equal(t, Formatter{}.ToString(hex), "#ff0000")
+	Equal(t, Formatter{ShortHex: true, UpperHex: true}.ToString(hex), "#F00")
+	Equal(t, Formatter{ShortHex: true}.ToString(rgba), "#5f55f5")
+	Equal(t, Formatter{HexAlpha: true}.ToString(rgba), "#5f55f588")
+	Equal(t, Formatter{HexAlpha: true, ShortHex: true}.ToString(gray), "#8889")
+	Equal(t, Formatter{HexAlpha: true, ShortHex: true}.ToString(rgba), "#5f55f588")
+	Equal(t, Formatter{HexAlpha: true}.ToString(hex), "#ff0000")
+
+	Equal(t, Formatter{Syntax: SyntaxRGB}.ToString(hex), "rgba(255,0,0,1)")
+	Equal(t, Formatter{Syntax: SyntaxRGB, OmitOpaqueAlpha: true}.ToString(hex), "rgb(255,0,0)")
+	Equal(t, Formatter{Syntax: SyntaxRGB}.ToString(rgba), rgba.String())
+	Equal(t, Formatter{Syntax: SyntaxRGB, AlphaPrecision: 2}.ToString(rgba), "rgba(95,85,245,0.53)")
+	Equal(t, Formatter{Syntax: SyntaxRGB, AlphaPrecision: 2}.ToString(gray), "rgba(136,136,136,0.6)")
+	Equal(t, Formatter{Syntax: SyntaxRGB, Modern: true, AlphaPrecision: 2}.ToString(rgba), "rgb(95 85 245 / 0.53)")
+	Equal(t, Formatter{Syntax: SyntaxRGB, AlphaPrecision: PrecisionDefault}.ToString(rgba), rgba.String())
+	Equal(t, Formatter{Syntax: SyntaxRGB, AlphaPrecision: PrecisionWhole}.ToString(gray), "rgba(136,136,136,1)")
+	Equal(t, Formatter{Syntax: SyntaxRGB, Modern: true, OmitOpaqueAlpha: true}.ToString(hex), "rgb(255 0 0)")
+	Equal(t, Formatter{Syntax: SyntaxRGB, Percent: true, OmitOpaqueAlpha: true}.ToString(gray), "rgba(53.33%,53.33%,53.33%,0.6)")
+	Equal(t, Formatter{Syntax: SyntaxRGB, Percent: true, Precision: PrecisionWhole, Modern: true}.ToString(gray), "rgb(53% 53% 53% / 0.6)")
+
+	Equal(t, Formatter{Syntax: SyntaxHSL, OmitOpaqueAlpha: true}.ToString(hex), "hsl(0,100%,50%)")
+	Equal(t, Formatter{Syntax: SyntaxHSL, Modern: true}.ToString(gray), "hsl(0 0% 53.33% / 0.6)")
+	Equal(t, Formatter{Syntax: SyntaxHSL, Precision: 1, AlphaPrecision: 3}.ToString(rgba), "hsla(243.8,88.9%,64.7%,0.535)")
+
+	Equal(t, Formatter{Syntax: SyntaxOKLCH, OmitOpaqueAlpha: true}.ToString(hex), "oklch(62.8% 0.2577 29.23)")
+	Equal(t, Formatter{Syntax: SyntaxOKLCH}.ToString(hex), "oklch(62.8% 0.2577 29.23 / 1)")
+	Equal(t, Formatter{Syntax: SyntaxOKLCH, Precision: 1}.ToString(gray), "oklch(62.7% 0 0 / 0.6)")
+}
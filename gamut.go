@@ -0,0 +1,153 @@
+package colors
+
+import "math"
+
+const (
+	// gamutJND is the deltaEOK below which a clipped color is indistinguishable from the
+	// chroma reduced color it was clipped from
+	gamutJND = 0.02
+
+	// gamutEpsilon is the chroma precision of the binary search
+	gamutEpsilon = 0.0001
+
+	// gamutTolerance is how far channels may lie outside [0, 1] while being considered
+	// within gamut, absorbing floating point error
+	gamutTolerance = 1e-6
+)
+
+// GamutMethod is the method used to bring colors within a gamut
+type GamutMethod uint8
+
+// Gamut mapping methods
+const (
+	// GamutCSS is the CSS Color 4 gamut mapping algorithm, reducing OKLCH chroma while keeping
+	// lightness and hue until clipping the result is indistinguishable from it
+	// https://www.w3.org/TR/css-color-4/#gamut-mapping
+	GamutCSS GamutMethod = iota
+
+	// GamutClip clamps each channel to [0, 1], which is fast but can shift hue and lightness
+	GamutClip
+)
+
+// unbounded reports whether the space has no gamut, as XYZ can represent every color
+func (s ColorSpace) unbounded() bool {
+	return s == SpaceXYZD50 || s == SpaceXYZD65
+}
+
+// InGamut reports whether c lies within the gamut of the space. Colors of every type other than
+// SpaceColor are within sRGB, and every color is within the XYZ spaces
+func InGamut(c Color, space ColorSpace) bool {
+
+	if space.unbounded() {
+		return true
+	}
+
+	return ToSpace(c, space).inGamut()
+}
+
+// inGamut reports whether the channels lie within [0, 1], allowing for floating point error
+func (c *SpaceColor) inGamut() bool {
+
+	in := func(v float64) bool {
+		return v >= -gamutTolerance && v <= 1+gamutTolerance
+	}
+
+	return c.Space.unbounded() || in(c.R) && in(c.G) && in(c.B)
+}
+
+// clip returns the color with its channels clamped to [0, 1]
+func (c *SpaceColor) clip() *SpaceColor {
+
+	clamp := func(v float64) float64 {
+		return math.Max(0, math.Min(1, v))
+	}
+
+	return &SpaceColor{Space: c.Space, R: clamp(c.R), G: clamp(c.G), B: clamp(c.B), A: c.A}
+}
+
+// oklab converts the color to OKLab
+func (c *SpaceColor) oklab() oklab {
+
+	lin := c.Convert(SpaceSRGBLinear)
+
+	return linearRGBToOKLab(lin.R, lin.G, lin.B)
+}
+
+// fromOKLab returns the OKLab color in the space, with alpha a
+func fromOKLab(c oklab, space ColorSpace, a float64) *SpaceColor {
+
+	r, g, b := c.linearRGB()
+
+	return (&SpaceColor{Space: SpaceSRGBLinear, R: r, G: g, B: b, A: a}).Convert(space)
+}
+
+// ToGamut converts c to the space, bringing it within the space's gamut using the method.
+// Colors already within gamut are converted unchanged, as are all colors for the XYZ spaces
+func ToGamut(c Color, space ColorSpace, method GamutMethod) *SpaceColor {
+
+	converted := ToSpace(c, space)
+
+	if converted.inGamut() {
+		return converted
+	}
+
+	if method == GamutClip {
+		return converted.clip()
+	}
+
+	origin := ToSpace(c, SpaceSRGBLinear).oklab()
+	l, ch, h := origin.lch()
+
+	// colors lighter than white or darker than black map to white or black, whatever their chroma
+	if l >= 1 {
+		return fromOKLab(oklab{L: 1}, space, converted.A).clip()
+	}
+
+	if l <= 0 {
+		return fromOKLab(oklab{}, space, converted.A).clip()
+	}
+
+	current := origin
+	clipped := converted.clip()
+
+	if clipped.oklab().distance(current) < gamutJND {
+		return clipped
+	}
+
+	lo, hi := 0.0, ch
+	loInGamut := true
+
+	for hi-lo > gamutEpsilon {
+
+		chroma := (lo + hi) / 2
+		current = fromLCH(l, chroma, h)
+		candidate := fromOKLab(current, space, converted.A)
+
+		if loInGamut && candidate.inGamut() {
+			lo = chroma
+			continue
+		}
+
+		clipped = candidate.clip()
+		e := clipped.oklab().distance(current)
+
+		if e < gamutJND {
+
+			if gamutJND-e < gamutEpsilon {
+				return clipped
+			}
+
+			loInGamut = false
+			lo = chroma
+		} else {
+			hi = chroma
+		}
+	}
+
+	// every chroma tried was within gamut, leaving clipped as the clipped origin
+	if loInGamut {
+		return fromOKLab(fromLCH(l, lo, h), space, converted.A).clip()
+	}
+
+	return clipped
+}
@@ -0,0 +1,64 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInGamut(t *testing.T) {
+
+	orange, _ := ParseHEX("#ff8800")
+	p3Red := &SpaceColor{Space: SpaceDisplayP3, R: 1, A: 1}
+	rec2020Green := &SpaceColor{Space: SpaceRec2020, G: 1, A: 1}
+
+	Equal(t, InGamut(orange, SpaceSRGB), true)
+	Equal(t, InGamut(orange, SpaceDisplayP3), true)
+	Equal(t, InGamut(p3Red, SpaceSRGB), false)
+	Equal(t, InGamut(p3Red, SpaceDisplayP3), true)
+	Equal(t, InGamut(&RGBColor{R: 255}, SpaceRec2020), true)
+	Equal(t, InGamut(&RGBColor{R: 255}, SpaceProPhotoRGB), true)
+	Equal(t, InGamut(rec2020Green, SpaceDisplayP3), false)
+	Equal(t, InGamut(rec2020Green, SpaceProPhotoRGB), true)
+	Equal(t, InGamut(rec2020Green, SpaceXYZD50), true)
+	Equal(t, InGamut(&SpaceColor{Space: SpaceSRGB, R: 1.0000001, A: 1}, SpaceSRGB), true)
+}
+
+func TestToGamut(t *testing.T) {
+
+	p3Red := &SpaceColor{Space: SpaceDisplayP3, R: 1, A: .5}
+
+	clipped := ToGamut(p3Red, SpaceSRGB, GamutClip)
+	Equal(t, clipped, &SpaceColor{Space: SpaceSRGB, R: 1, A: .5})
+
+	mapped := ToGamut(p3Red, SpaceSRGB, GamutCSS)
+	Equal(t, mapped.Space, SpaceSRGB)
+	Equal(t, mapped.A, .5)
+	Equal(t, mapped.inGamut(), true)
+
+	hue := func(c *SpaceColor) float64 {
+		_, _, h := c.oklab().lch()
+		return h
+	}
+
+	// chroma reduction keeps the hue that clipping shifts, within the final clip of a JND
+	for _, c := range []*SpaceColor{p3Red, {Space: SpaceRec2020, G: 1, A: 1}, {Space: SpaceDisplayP3, B: 1, A: 1}} {
+
+		mapped := ToGamut(c, SpaceSRGB, GamutCSS)
+		clipped := ToGamut(c, SpaceSRGB, GamutClip)
+
+		Equal(t, math.Abs(hue(mapped)-hue(c)) < 3, true)
+		Equal(t, math.Abs(hue(mapped)-hue(c)) <= math.Abs(hue(clipped)-hue(c)), true)
+	}
+
+	// colors within gamut or converted to XYZ are unchanged
+	inside := &SpaceColor{Space: SpaceDisplayP3, R: .5, G: .4, B: .3, A: 1}
+	Equal(t, ToGamut(inside, SpaceDisplayP3, GamutCSS), inside)
+	Equal(t, ToGamut(p3Red, SpaceXYZD65, GamutCSS), p3Red.Convert(SpaceXYZD65))
+
+	// colors beyond white or black map to them
+	Equal(t, ToGamut(&SpaceColor{Space: SpaceSRGB, R: 1.2, G: 1.2, B: 1.1, A: 1}, SpaceSRGB, GamutCSS).ToHEX().String(), "#ffffff")
+	Equal(t, ToGamut(&SpaceColor{Space: SpaceSRGBLinear, R: -.1, G: -.1, B: -.1, A: 1}, SpaceSRGB, GamutCSS).ToHEX().String(), "#000000")
+
+	orange, _ := ParseHEX("#ff8800")
+	Equal(t, ToGamut(orange, SpaceSRGB, GamutCSS).ToHEX().String(), "#ff8800")
+}
@@ -0,0 +1,113 @@
+package colors
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrBadHarmony is returned when parsing an unknown harmony name
+	ErrBadHarmony = errors.New("colors: unknown harmony")
+)
+
+// Harmony is a color scheme of colors related by hue or lightness
+type Harmony uint8
+
+// Harmonies
+const (
+	// HarmonyComplementary is the color and the color opposite it on the hue wheel
+	HarmonyComplementary Harmony = iota
+
+	// HarmonyAnalogous is the color and its neighbors 30 degrees either side
+	HarmonyAnalogous
+
+	// HarmonyTriadic is the color and the colors 120 degrees either side
+	HarmonyTriadic
+
+	// HarmonySplitComplementary is the color and the colors either side of its complement
+	HarmonySplitComplementary
+
+	// HarmonyTetradic is the color and the colors at 90 degree intervals
+	HarmonyTetradic
+
+	// HarmonyMonochromatic is five shades of the color's hue from dark to light, including the color
+	HarmonyMonochromatic
+)
+
+var harmonyNames = map[Harmony]string{
+	HarmonyComplementary:      "complementary",
+	HarmonyAnalogous:          "analogous",
+	HarmonyTriadic:            "triadic",
+	HarmonySplitComplementary: "split-complementary",
+	HarmonyTetradic:           "tetradic",
+	HarmonyMonochromatic:      "monochromatic",
+}
+
+var harmonyHues = map[Harmony][]float64{
+	HarmonyComplementary:      {0, 180},
+	HarmonyAnalogous:          {-30, 0, 30},
+	HarmonyTriadic:            {0, 120, 240},
+	HarmonySplitComplementary: {0, 150, 210},
+	HarmonyTetradic:           {0, 90, 180, 270},
+}
+
+// String returns the Harmony's name, e.g. split-complementary
+func (h Harmony) String() string {
+	return harmonyNames[h]
+}
+
+// ParseHarmony parses a Harmony's name as returned by String, or returns ErrBadHarmony
+func ParseHarmony(name string) (Harmony, error) {
+
+	for h, n := range harmonyNames {
+		if n == name {
+			return h, nil
+		}
+	}
+
+	return 0, ErrBadHarmony
+}
+
+// Harmonize returns the colors of the harmony based on c, starting with c itself except for
+// analogous and monochromatic schemes which are ordered by hue and lightness. Hues are rotated
+// and shades generated in OKLCH so colors keep the perceived lightness and chroma of c, chroma
+// being reduced where needed to stay within sRGB. Colors are RGBColors, or RGBAColors with the
+// alpha of c when c is translucent
+func Harmonize(c Color, h Harmony) []Color {
+
+	rgba := c.ToRGBA()
+	l, ch, hue := rgbToOKLab(rgba.R, rgba.G, rgba.B).lch()
+
+	var offsets []float64
+
+	if h == HarmonyMonochromatic {
+		offsets = []float64{-.3, -.15, 0, .15, .3}
+	} else {
+		offsets = harmonyHues[h]
+	}
+
+	colors := make([]Color, len(offsets))
+
+	for i, offset := range offsets {
+
+		// keep c itself exactly, avoiding any rounding of the round trip through OKLCH
+		rgb := rgba.ToRGB()
+
+		switch {
+		case offset == 0:
+		case h == HarmonyMonochromatic:
+			rgb = fromLCH(math.Max(.05, math.Min(.98, l+offset)), ch, hue).toGamutRGB()
+		default:
+			rgb = fromLCH(l, ch, math.Mod(hue+offset+360, 360)).toGamutRGB()
+		}
+
+		if rgba.A < 1 {
+			colors[i] = &RGBAColor{R: rgb.R, G: rgb.G, B: rgb.B, A: rgba.A}
+			continue
+		}
+
+		colors[i] = rgb
+	}
+
+	return colors
+}
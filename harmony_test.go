@@ -0,0 +1,75 @@
+package colors
+
+import (
+	"testing"
+)
+
+func TestHarmonize(t *testing.T) {
+
+	orange, _ := ParseHEX("#ff8800")
+
+	strs := func(colors []Color) []string {
+		s := make([]string, len(colors))
+		for i, c := range colors {
+			s[i] = c.ToHEX().String()
+		}
+		return s
+	}
+
+	Equal(t, strs(Harmonize(orange, HarmonyComplementary)), []string{"#ff8800", "#26b9ff"})
+	Equal(t, len(Harmonize(orange, HarmonyAnalogous)), 3)
+	Equal(t, Harmonize(orange, HarmonyAnalogous)[1].ToHEX().String(), "#ff8800")
+	Equal(t, len(Harmonize(orange, HarmonyTriadic)), 3)
+	Equal(t, len(Harmonize(orange, HarmonySplitComplementary)), 3)
+	Equal(t, len(Harmonize(orange, HarmonyTetradic)), 4)
+
+	shades := Harmonize(orange, HarmonyMonochromatic)
+	Equal(t, len(shades), 5)
+	Equal(t, shades[2].ToHEX().String(), "#ff8800")
+
+	for i := 1; i < len(shades); i++ {
+		Equal(t, Luminance(shades[i]) > Luminance(shades[i-1]), true)
+	}
+
+	// rotated hues keep the lightness of the color
+	for _, c := range Harmonize(orange, HarmonyTriadic) {
+		rgb := c.ToRGB()
+		l, _, _ := rgbToOKLab(rgb.R, rgb.G, rgb.B).lch()
+		Equal(t, l > .73 && l < .76, true)
+	}
+
+	blue, _ := RGBA(0, 0, 255, 0.5)
+
+	for _, c := range Harmonize(blue, HarmonyTetradic) {
+		Equal(t, c.ToRGBA().A, 0.5)
+	}
+}
+
+func TestParseHarmony(t *testing.T) {
+
+	for h := HarmonyComplementary; h <= HarmonyMonochromatic; h++ {
+		parsed, err := ParseHarmony(h.String())
+		Equal(t, err, nil)
+		Equal(t, parsed, h)
+	}
+
+	_, err := ParseHarmony("pentadic")
+	Equal(t, err, ErrBadHarmony)
+}
+
+func TestOKLabToGamutRGB(t *testing.T) {
+
+	// a vivid green beyond sRGB keeps its lightness and hue with reduced chroma
+	lab := fromLCH(.8, .4, 140)
+	Equal(t, lab.inGamut(), false)
+
+	rgb := lab.toGamutRGB()
+	l, ch, h := rgbToOKLab(rgb.R, rgb.G, rgb.B).lch()
+
+	Equal(t, l > .79 && l < .81, true)
+	Equal(t, ch < .4, true)
+	Equal(t, h > 138 && h < 142, true)
+
+	Equal(t, fromLCH(1.2, .1, 0).toGamutRGB().String(), "rgb(255,255,255)")
+	Equal(t, fromLCH(-.1, .1, 0).toGamutRGB().String(), "rgb(0,0,0)")
+}
@@ -0,0 +1,119 @@
+package colors
+
+import (
+	"hash/fnv"
+)
+
+// Default OKLCH band of hashed colors, bright enough to tell apart without being garish
+const (
+	defaultHashMinLightness = .55
+	defaultHashMaxLightness = .75
+	defaultHashMinChroma    = .1
+	defaultHashMaxChroma    = .16
+)
+
+// Hasher maps arbitrary strings and bytes, e.g. user IDs or chart series names, to stable colors
+// within an OKLCH lightness and chroma band, so that they look consistent across a UI. Its zero
+// value hashes into a lightness of .55 to .75 and chroma of .1 to .16
+type Hasher struct {
+	// MinLightness and MaxLightness bound the OKLCH lightness in [0, 1], both 0 using .55 to .75
+	MinLightness, MaxLightness float64
+
+	// MinChroma and MaxChroma bound the OKLCH chroma, both 0 using .1 to .16. Chroma is reduced
+	// below MinChroma where the hue cannot reach it within sRGB at the chosen lightness
+	MinChroma, MaxChroma float64
+
+	// Background is the color hashed colors must contrast with, nil disabling the check
+	Background Color
+
+	// MinContrast is the WCAG 2 contrast ratio hashed colors must have with Background, e.g.
+	// ContrastAALarge. Lightness is moved away from Background, leaving the band when needed,
+	// until the ratio is met or the color is black or white
+	MinContrast float64
+}
+
+// HashString returns the stable color of s, see Hash
+func (h Hasher) HashString(s string) Color {
+	return h.Hash([]byte(s))
+}
+
+// Hash returns the stable color of b, the same bytes always hashing to the same color for the
+// same Hasher across processes, platforms and versions
+func (h Hasher) Hash(b []byte) Color {
+
+	f := fnv.New64a()
+	_, _ = f.Write(b)
+
+	sum := mix64(f.Sum64())
+
+	// independent bits of the hash pick the hue, lightness and chroma
+	hue := float64(sum&0xffff) / 0x10000 * 360
+	lf := float64(sum>>16&0xffff) / 0xffff
+	cf := float64(sum>>32&0xffff) / 0xffff
+
+	minL, maxL := h.MinLightness, h.MaxLightness
+	if minL == 0 && maxL == 0 {
+		minL, maxL = defaultHashMinLightness, defaultHashMaxLightness
+	}
+
+	minC, maxC := h.MinChroma, h.MaxChroma
+	if minC == 0 && maxC == 0 {
+		minC, maxC = defaultHashMinChroma, defaultHashMaxChroma
+	}
+
+	l := minL + (maxL-minL)*lf
+	ch := minC + (maxC-minC)*cf
+
+	rgb := fromLCH(l, ch, hue).toGamutRGB()
+
+	if h.Background == nil || h.MinContrast <= 0 || Contrast(rgb, h.Background) >= h.MinContrast {
+		return rgb
+	}
+
+	return h.contrasting(l, ch, hue, rgb)
+}
+
+// contrasting returns the color of the hue and chroma closest in lightness to l that meets
+// MinContrast against Background, or the color of the hue with the most contrast when none does
+func (h Hasher) contrasting(l, ch, hue float64, best *RGBColor) Color {
+
+	const step = .01
+
+	bestContrast := Contrast(best, h.Background)
+
+	for d := step; d <= 1; d += step {
+
+		for _, candidate := range []float64{l - d, l + d} {
+
+			if candidate < 0 || candidate > 1 {
+				continue
+			}
+
+			rgb := fromLCH(candidate, ch, hue).toGamutRGB()
+			contrast := Contrast(rgb, h.Background)
+
+			if contrast >= h.MinContrast {
+				return rgb
+			}
+
+			if contrast > bestContrast {
+				best, bestContrast = rgb, contrast
+			}
+		}
+	}
+
+	return best
+}
+
+// mix64 is the finalizer of MurmurHash3, spreading every input bit across the output so that
+// similar strings, e.g. user-1 and user-2, hash to unrelated colors
+func mix64(x uint64) uint64 {
+
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb3cc7bb57a39
+	x ^= x >> 33
+
+	return x
+}
@@ -0,0 +1,55 @@
+package colors
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestHasher(t *testing.T) {
+
+	// hashed colors must never change between releases
+	Equal(t, Hasher{}.HashString("alice").ToHEX().String(), "#61852a")
+	Equal(t, Hasher{}.HashString("user-1").ToHEX().String(), "#b96dcb")
+	Equal(t, Hasher{}.HashString("user-2").ToHEX().String(), "#289a63")
+	Equal(t, Hasher{}.Hash([]byte("alice")).ToHEX().String(), "#61852a")
+
+	h := Hasher{MinLightness: .3, MaxLightness: .4, MinChroma: .05, MaxChroma: .08}
+	seen := make(map[string]bool)
+
+	for i := 0; i < 200; i++ {
+
+		rgb := h.HashString(strconv.Itoa(i)).ToRGB()
+		l, ch, _ := rgbToOKLab(rgb.R, rgb.G, rgb.B).lch()
+
+		// allow for rounding to 8-bit channels
+		Equal(t, l > .29 && l < .41, true)
+		Equal(t, ch < .09, true)
+
+		seen[rgb.String()] = true
+	}
+
+	Equal(t, len(seen) > 190, true)
+}
+
+func TestHasherContrast(t *testing.T) {
+
+	white, _ := ParseHEX("#fff")
+	black, _ := ParseHEX("#000")
+
+	for _, bg := range []Color{white, black} {
+
+		h := Hasher{Background: bg, MinContrast: ContrastAA}
+
+		for i := 0; i < 100; i++ {
+			Equal(t, Contrast(h.HashString(strconv.Itoa(i)), bg) >= ContrastAA, true)
+		}
+	}
+
+	// colors already meeting the contrast are unchanged
+	Equal(t, Hasher{Background: black, MinContrast: 1.5}.HashString("alice").ToHEX().String(), "#61852a")
+
+	// the best available contrast is returned when the minimum is unreachable
+	gray, _ := ParseHEX("#777")
+	c := Hasher{Background: gray, MinContrast: ContrastAAA}.HashString("alice")
+	Equal(t, Contrast(c, gray) > 4, true)
+}
@@ -0,0 +1,82 @@
+package colors
+
+import "math"
+
+// rgbToHSL converts 8-bit sRGB channels to hue in degrees [0, 360) and saturation and lightness in [0, 1]
+func rgbToHSL(r, g, b uint8) (h, s, l float64) {
+
+	rf := float64(r) / 255
+	gf := float64(g) / 255
+	bf := float64(b) / 255
+
+	hi := math.Max(rf, math.Max(gf, bf))
+	lo := math.Min(rf, math.Min(gf, bf))
+	l = (hi + lo) / 2
+
+	if hi == lo {
+		return 0, 0, l
+	}
+
+	d := hi - lo
+
+	if l > 0.5 {
+		s = d / (2 - hi - lo)
+	} else {
+		s = d / (hi + lo)
+	}
+
+	switch hi {
+	case rf:
+		h = (gf - bf) / d
+		if gf < bf {
+			h += 6
+		}
+	case gf:
+		h = (bf-rf)/d + 2
+	default:
+		h = (rf-gf)/d + 4
+	}
+
+	return h * 60, s, l
+}
+
+// hsvToRGB converts hue in degrees and saturation and value in [0, 1] to sRGB channels in [0, 1]
+func hsvToRGB(h, s, v float64) (r, g, b float64) {
+
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return r + m, g + m, b + m
+}
+
+// hslToRGB converts hue in degrees and saturation and lightness in [0, 1] to sRGB channels in [0, 1]
+func hslToRGB(h, s, l float64) (r, g, b float64) {
+
+	v := l + s*math.Min(l, 1-l)
+	if v == 0 {
+		return 0, 0, 0
+	}
+
+	return hsvToRGB(h, 2*(1-l/v), v)
+}
@@ -0,0 +1,52 @@
+package colors
+
+import "math"
+
+// Inspection is a color in every notation the package formats, with its WCAG 2 relative
+// luminance and nearest xterm-256 color
+type Inspection struct {
+	HEX       string   `json:"hex"`
+	RGB       string   `json:"rgb"`
+	RGBA      string   `json:"rgba"`
+	HSL       string   `json:"hsl"`
+	OKLCH     string   `json:"oklch"`
+	ANSI256   uint8    `json:"ansi256"`
+	Luminance float64  `json:"luminance"`
+	Light     bool     `json:"light"`
+	Dark      bool     `json:"dark"`
+	Channels  Channels `json:"channels"`
+}
+
+// Channels are a color's 8-bit red, green and blue channels and its alpha in [0, 1]
+type Channels struct {
+	R uint8   `json:"r"`
+	G uint8   `json:"g"`
+	B uint8   `json:"b"`
+	A float64 `json:"a"`
+}
+
+// Inspect returns the Inspection of the color, hex keeping the alpha of translucent colors,
+// rgb discarding it and the luminance being rounded to 4 decimals
+func Inspect(c Color) Inspection {
+
+	rgba := c.ToRGBA()
+
+	return Inspection{
+		HEX:       Formatter{HexAlpha: true}.ToString(c),
+		RGB:       Formatter{Syntax: SyntaxRGB, OmitOpaqueAlpha: true}.ToString(c.ToRGB()),
+		RGBA:      Formatter{Syntax: SyntaxRGB}.ToString(c),
+		HSL:       Formatter{Syntax: SyntaxHSL, OmitOpaqueAlpha: true}.ToString(c),
+		OKLCH:     Formatter{Syntax: SyntaxOKLCH, OmitOpaqueAlpha: true}.ToString(c),
+		ANSI256:   XTerm256.Index(c),
+		Luminance: round(Luminance(c), 4),
+		Light:     c.IsLight(),
+		Dark:      c.IsDark(),
+		Channels:  Channels{R: rgba.R, G: rgba.G, B: rgba.B, A: rgba.A},
+	}
+}
+
+// round rounds v to the given number of decimals
+func round(v float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(v*p) / p
+}
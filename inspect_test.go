@@ -0,0 +1,28 @@
+package colors
+
+import "testing"
+
+func TestInspect(t *testing.T) {
+
+	orange, _ := ParseHEX("#ff8800")
+
+	Equal(t, Inspect(orange), Inspection{
+		HEX:       "#ff8800",
+		RGB:       "rgb(255,136,0)",
+		RGBA:      "rgba(255,136,0,1)",
+		HSL:       "hsl(32,100%,50%)",
+		OKLCH:     "oklch(74.42% 0.1812 56.46)",
+		ANSI256:   208,
+		Luminance: 0.3887,
+		Light:     true,
+		Channels:  Channels{R: 255, G: 136, A: 1},
+	})
+
+	blue, _ := RGBA(0, 0, 255, 0.5)
+	in := Inspect(blue)
+
+	Equal(t, in.HEX, "#0000ff80")
+	Equal(t, in.RGB, "rgb(0,0,255)")
+	Equal(t, in.Channels.A, 0.5)
+	Equal(t, in.Dark, true)
+}
@@ -0,0 +1,147 @@
+package colors
+
+import "sort"
+
+// kdNode is a node of a 3 dimensional k-d tree over OKLab colors
+type kdNode struct {
+	point oklab
+	index int
+	axis  int
+	left  *kdNode
+	right *kdNode
+}
+
+// kdMatch is a point found by a k-d tree search and its distance from the target
+type kdMatch struct {
+	index    int
+	distance float64
+}
+
+func axisValue(p oklab, axis int) float64 {
+	switch axis {
+	case 0:
+		return p.L
+	case 1:
+		return p.A
+	default:
+		return p.B
+	}
+}
+
+// buildKDTree builds a balanced k-d tree from the points, indexes being the
+// positions within the original slice
+func buildKDTree(points []oklab) *kdNode {
+
+	indexes := make([]int, len(points))
+
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	return buildKDNode(points, indexes, 0)
+}
+
+func buildKDNode(points []oklab, indexes []int, depth int) *kdNode {
+
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	axis := depth % 3
+
+	sort.Slice(indexes, func(i, j int) bool {
+		return axisValue(points[indexes[i]], axis) < axisValue(points[indexes[j]], axis)
+	})
+
+	mid := len(indexes) / 2
+
+	return &kdNode{
+		point: points[indexes[mid]],
+		index: indexes[mid],
+		axis:  axis,
+		left:  buildKDNode(points, indexes[:mid], depth+1),
+		right: buildKDNode(points, indexes[mid+1:], depth+1),
+	}
+}
+
+// nearest returns up to k matches nearest to target ordered by distance ascending
+func (n *kdNode) nearest(target oklab, k int) []kdMatch {
+
+	if k <= 0 {
+		return nil
+	}
+
+	matches := make([]kdMatch, 0, k)
+	n.searchNearest(target, k, &matches)
+
+	return matches
+}
+
+func (n *kdNode) searchNearest(target oklab, k int, matches *[]kdMatch) {
+
+	if n == nil {
+		return
+	}
+
+	insertMatch(matches, kdMatch{index: n.index, distance: target.distance(n.point)}, k)
+
+	diff := axisValue(target, n.axis) - axisValue(n.point, n.axis)
+	near, far := n.left, n.right
+
+	if diff > 0 {
+		near, far = far, near
+	}
+
+	near.searchNearest(target, k, matches)
+
+	// only search the far side when it could hold a closer point than the furthest found
+	if len(*matches) < k || diff*diff <= (*matches)[len(*matches)-1].distance*(*matches)[len(*matches)-1].distance {
+		far.searchNearest(target, k, matches)
+	}
+}
+
+// insertMatch inserts m into the sorted matches, keeping at most k
+func insertMatch(matches *[]kdMatch, m kdMatch, k int) {
+
+	ms := *matches
+
+	if len(ms) == k && m.distance >= ms[k-1].distance {
+		return
+	}
+
+	i := sort.Search(len(ms), func(i int) bool {
+		return ms[i].distance > m.distance
+	})
+
+	if len(ms) < k {
+		ms = append(ms, kdMatch{})
+	}
+
+	copy(ms[i+1:], ms[i:])
+	ms[i] = m
+	*matches = ms
+}
+
+// within appends every point no further than radius from target to matches
+func (n *kdNode) within(target oklab, radius float64, matches []kdMatch) []kdMatch {
+
+	if n == nil {
+		return matches
+	}
+
+	if d := target.distance(n.point); d <= radius {
+		matches = append(matches, kdMatch{index: n.index, distance: d})
+	}
+
+	diff := axisValue(target, n.axis) - axisValue(n.point, n.axis)
+
+	if diff <= radius {
+		matches = n.left.within(target, radius, matches)
+	}
+
+	if diff >= -radius {
+		matches = n.right.within(target, radius, matches)
+	}
+
+	return matches
+}
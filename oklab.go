@@ -0,0 +1,173 @@
+package colors
+
+import "math"
+
+// oklab represents a color in the OKLab perceptual color space
+// https://bottosson.github.io/posts/oklab/
+type oklab struct {
+	L float64
+	A float64
+	B float64
+}
+
+// srgbToLinear converts a gamma encoded sRGB channel in the range [0, 1] to linear light
+func srgbToLinear(v float64) float64 {
+
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB converts a linear light channel in the range [0, 1] to gamma encoded sRGB
+func linearToSRGB(v float64) float64 {
+
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// clampUint8 rounds and clamps a channel in the range [0, 1] to [0, 255]
+func clampUint8(v float64) uint8 {
+
+	v = math.Floor(v*255 + .5)
+
+	if v <= 0 {
+		return 0
+	}
+
+	if v >= 255 {
+		return 255
+	}
+
+	return uint8(v)
+}
+
+// rgbToOKLab converts 8-bit sRGB channels to OKLab
+func rgbToOKLab(r, g, b uint8) oklab {
+	return linearRGBToOKLab(
+		srgbToLinear(float64(r)/255),
+		srgbToLinear(float64(g)/255),
+		srgbToLinear(float64(b)/255),
+	)
+}
+
+// linearRGBToOKLab converts linear sRGB channels to OKLab
+func linearRGBToOKLab(r, g, b float64) oklab {
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return oklab{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// linearRGB converts the OKLab color to linear sRGB channels, the result may fall outside of [0, 1]
+func (c oklab) linearRGB() (r, g, b float64) {
+
+	l := c.L + 0.3963377774*c.A + 0.2158037573*c.B
+	m := c.L - 0.1055613458*c.A - 0.0638541728*c.B
+	s := c.L - 0.0894841775*c.A - 1.2914855480*c.B
+
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	r = 4.0767416621*l - 3.3077115913*m + 0.2309699292*s
+	g = -1.2684380046*l + 2.6097574011*m - 0.3413193965*s
+	b = -0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+
+	return
+}
+
+// toRGB converts the OKLab color to an RGBColor, clipping any channels that fall outside of sRGB
+func (c oklab) toRGB() *RGBColor {
+
+	r, g, b := c.linearRGB()
+
+	return &RGBColor{
+		R: clampUint8(linearToSRGB(math.Max(r, 0))),
+		G: clampUint8(linearToSRGB(math.Max(g, 0))),
+		B: clampUint8(linearToSRGB(math.Max(b, 0))),
+	}
+}
+
+// distance returns the euclidean distance, deltaEOK, between two OKLab colors
+func (c oklab) distance(d oklab) float64 {
+
+	dl := c.L - d.L
+	da := c.A - d.A
+	db := c.B - d.B
+
+	return math.Sqrt(dl*dl + da*da + db*db)
+}
+
+// lch returns the OKLCH lightness, chroma and hue in degrees [0, 360) of the OKLab color
+func (c oklab) lch() (l, ch, h float64) {
+
+	ch = math.Hypot(c.A, c.B)
+	h = math.Atan2(c.B, c.A) * 180 / math.Pi
+
+	if h < 0 {
+		h += 360
+	}
+
+	return c.L, ch, h
+}
+
+// fromLCH returns the OKLab color of OKLCH lightness, chroma and hue in degrees
+func fromLCH(l, ch, h float64) oklab {
+
+	rad := h * math.Pi / 180
+
+	return oklab{L: l, A: ch * math.Cos(rad), B: ch * math.Sin(rad)}
+}
+
+// inGamut reports whether the OKLab color lies within sRGB
+func (c oklab) inGamut() bool {
+
+	const eps = 1e-6
+
+	r, g, b := c.linearRGB()
+
+	return r >= -eps && r <= 1+eps && g >= -eps && g <= 1+eps && b >= -eps && b <= 1+eps
+}
+
+// toGamutRGB converts the OKLab color to an RGBColor, reducing its chroma while keeping its
+// lightness and hue until it lies within sRGB
+func (c oklab) toGamutRGB() *RGBColor {
+
+	if c.inGamut() {
+		return c.toRGB()
+	}
+
+	l, ch, h := c.lch()
+
+	if l <= 0 {
+		return &RGBColor{}
+	}
+
+	if l >= 1 {
+		return &RGBColor{R: 255, G: 255, B: 255}
+	}
+
+	lo, hi := 0.0, ch
+
+	for i := 0; i < 24; i++ {
+
+		mid := (lo + hi) / 2
+
+		if fromLCH(l, mid, h).inGamut() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return fromLCH(l, lo, h).toRGB()
+}
@@ -0,0 +1,239 @@
+package colors
+
+import (
+	"sort"
+	"sync"
+)
+
+// NamedColor is a Color along with its name
+type NamedColor struct {
+	Name  string
+	Color Color
+}
+
+// PaletteMatch is a palette entry found by a search along with its distance, deltaEOK, from the searched color
+type PaletteMatch struct {
+	NamedColor
+	Distance float64
+}
+
+// Palette is a set of uniquely named Colors supporting fast nearest color searches.
+// Searches use a k-d tree in the OKLab color space, distances being deltaEOK, and ignore alpha.
+// The tree is rebuilt, in O(n log n), by the first search after the palette changes, so
+// that adding many colors costs a single rebuild.
+// It is safe for concurrent use and the zero value is an empty palette ready to use
+type Palette struct {
+	mu      sync.RWMutex
+	entries []NamedColor
+	index   map[string]int
+	tree    *kdNode
+
+	// stale records that the entries changed since the tree was built
+	stale bool
+}
+
+// NewPalette returns a new Palette containing the provided colors, later
+// colors replacing earlier ones of the same name and nil colors being ignored
+func NewPalette(colors ...NamedColor) *Palette {
+
+	p := &Palette{index: make(map[string]int, len(colors))}
+
+	for _, c := range colors {
+		p.set(c.Name, c.Color)
+	}
+
+	p.rebuild()
+
+	return p
+}
+
+// set adds or replaces the named color without rebuilding the search tree, ignoring nil colors
+func (p *Palette) set(name string, c Color) {
+
+	if c == nil {
+		return
+	}
+
+	if p.index == nil {
+		p.index = make(map[string]int)
+	}
+
+	if i, ok := p.index[name]; ok {
+		p.entries[i].Color = c
+		return
+	}
+
+	p.index[name] = len(p.entries)
+	p.entries = append(p.entries, NamedColor{Name: name, Color: c})
+}
+
+// rebuild rebuilds the search tree from the current entries
+func (p *Palette) rebuild() {
+
+	points := make([]oklab, len(p.entries))
+
+	for i, e := range p.entries {
+		rgb := e.Color.ToRGB()
+		points[i] = rgbToOKLab(rgb.R, rgb.G, rgb.B)
+	}
+
+	p.tree = buildKDTree(points)
+	p.stale = false
+}
+
+// rlockTree acquires the read lock, first rebuilding the search tree when the palette changed since it was built
+func (p *Palette) rlockTree() {
+
+	p.mu.RLock()
+
+	// the palette may change again between releasing the write lock and reacquiring the read lock
+	for p.stale {
+
+		p.mu.RUnlock()
+		p.mu.Lock()
+
+		if p.stale {
+			p.rebuild()
+		}
+
+		p.mu.Unlock()
+		p.mu.RLock()
+	}
+}
+
+// Add adds the named color to the palette, replacing any existing color of the same name.
+// A nil color is ignored
+func (p *Palette) Add(name string, c Color) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.set(name, c)
+	p.stale = true
+}
+
+// Remove removes the named color from the palette, reporting whether it was present
+func (p *Palette) Remove(name string) bool {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i, ok := p.index[name]
+	if !ok {
+		return false
+	}
+
+	p.entries = append(p.entries[:i], p.entries[i+1:]...)
+	delete(p.index, name)
+
+	for j := i; j < len(p.entries); j++ {
+		p.index[p.entries[j].Name] = j
+	}
+
+	p.stale = true
+
+	return true
+}
+
+// Get returns the named color and whether it is part of the palette
+func (p *Palette) Get(name string) (Color, bool) {
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	i, ok := p.index[name]
+	if !ok {
+		return nil, false
+	}
+
+	return p.entries[i].Color, true
+}
+
+// Len returns the number of colors in the palette
+func (p *Palette) Len() int {
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return len(p.entries)
+}
+
+// Colors returns a copy of the palette's colors in the order they were added
+func (p *Palette) Colors() []NamedColor {
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return append([]NamedColor(nil), p.entries...)
+}
+
+// Nearest returns the palette color nearest to c, ok being false when the palette is empty
+func (p *Palette) Nearest(c Color) (match PaletteMatch, ok bool) {
+
+	matches := p.KNearest(c, 1)
+
+	if len(matches) == 0 {
+		return PaletteMatch{}, false
+	}
+
+	return matches[0], true
+}
+
+// NearestWithin returns the palette color nearest to c, ok being false when
+// no color is within the maxDistance deltaEOK threshold
+func (p *Palette) NearestWithin(c Color, maxDistance float64) (match PaletteMatch, ok bool) {
+
+	match, ok = p.Nearest(c)
+
+	if !ok || match.Distance > maxDistance {
+		return PaletteMatch{}, false
+	}
+
+	return match, true
+}
+
+// KNearest returns up to k palette colors nearest to c ordered by distance ascending
+func (p *Palette) KNearest(c Color, k int) []PaletteMatch {
+
+	rgb := c.ToRGB()
+	target := rgbToOKLab(rgb.R, rgb.G, rgb.B)
+
+	p.rlockTree()
+	defer p.mu.RUnlock()
+
+	return p.matches(p.tree.nearest(target, k))
+}
+
+// Within returns every palette color within the maxDistance deltaEOK threshold of c ordered by distance ascending
+func (p *Palette) Within(c Color, maxDistance float64) []PaletteMatch {
+
+	rgb := c.ToRGB()
+	target := rgbToOKLab(rgb.R, rgb.G, rgb.B)
+
+	p.rlockTree()
+	defer p.mu.RUnlock()
+
+	found := p.tree.within(target, maxDistance, nil)
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].distance < found[j].distance
+	})
+
+	return p.matches(found)
+}
+
+// matches converts tree matches into PaletteMatches, the read lock must be held
+func (p *Palette) matches(found []kdMatch) []PaletteMatch {
+
+	if len(found) == 0 {
+		return nil
+	}
+
+	matches := make([]PaletteMatch, len(found))
+
+	for i, m := range found {
+		matches[i] = PaletteMatch{NamedColor: p.entries[m.index], Distance: m.distance}
+	}
+
+	return matches
+}
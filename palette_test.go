@@ -0,0 +1,169 @@
+package colors
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestPalette(t *testing.T) {
+
+	red, _ := ParseHEX("#ff0000")
+	green, _ := ParseHEX("#00ff00")
+	blue, _ := ParseHEX("#0000ff")
+	darkRed, _ := RGB(128, 0, 0)
+
+	p := NewPalette(
+		NamedColor{Name: "red", Color: red},
+		NamedColor{Name: "green", Color: green},
+		NamedColor{Name: "blue", Color: blue},
+	)
+	Equal(t, p.Len(), 3)
+
+	target, _ := RGB(240, 10, 10)
+
+	m, ok := p.Nearest(target)
+	Equal(t, ok, true)
+	Equal(t, m.Name, "red")
+	Equal(t, m.Distance > 0, true)
+
+	_, ok = p.NearestWithin(target, 0.01)
+	Equal(t, ok, false)
+	m, ok = p.NearestWithin(target, 0.1)
+	Equal(t, ok, true)
+	Equal(t, m.Name, "red")
+
+	p.Add("dark red", darkRed)
+	matches := p.KNearest(target, 2)
+	Equal(t, len(matches), 2)
+	Equal(t, matches[0].Name, "red")
+	Equal(t, matches[1].Name, "dark red")
+	Equal(t, len(p.KNearest(target, 10)), 4)
+
+	matches = p.Within(red, 0.3)
+	Equal(t, len(matches), 2)
+	Equal(t, matches[0].Distance, 0.0)
+
+	c, ok := p.Get("green")
+	Equal(t, ok, true)
+	Equal(t, c.String(), "#00ff00")
+
+	p.Add("green", blue)
+	Equal(t, p.Len(), 4)
+	Equal(t, p.Remove("red"), true)
+	Equal(t, p.Remove("red"), false)
+	Equal(t, p.Len(), 3)
+	Equal(t, p.Colors()[0].Name, "green")
+	Equal(t, p.Colors()[0].Color.String(), "#0000ff")
+
+	m, _ = p.Nearest(target)
+	Equal(t, m.Name, "dark red")
+
+	var empty Palette
+	_, ok = empty.Nearest(target)
+	Equal(t, ok, false)
+	Equal(t, empty.Within(target, 1), nil)
+	empty.Add("red", red)
+	Equal(t, empty.Len(), 1)
+
+	p.Add("nil", nil)
+	Equal(t, p.Len(), 3)
+	_, ok = p.Get("nil")
+	Equal(t, ok, false)
+	Equal(t, NewPalette(NamedColor{Name: "nil"}).Len(), 0)
+}
+
+func TestPaletteConcurrentChanges(t *testing.T) {
+
+	var p Palette
+	var wg sync.WaitGroup
+
+	for g := 0; g < 4; g++ {
+
+		wg.Add(2)
+
+		go func(g int) {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				name := string(rune('a'+g)) + string(rune('a'+i%26))
+				p.Add(name, &RGBColor{R: uint8(i), G: uint8(g), B: 128})
+				if i%3 == 0 {
+					p.Remove(name)
+				}
+			}
+		}(g)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				if m, ok := p.Nearest(&RGBColor{R: uint8(i), B: 128}); ok && m.Color == nil {
+					t.Error("expected a match to have a color")
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	m, ok := p.Nearest(&RGBColor{R: 98, G: 3, B: 128})
+	Equal(t, ok, true)
+	Equal(t, m.Distance, 0.0)
+}
+
+func TestPaletteMatchesLinearScan(t *testing.T) {
+
+	rnd := rand.New(rand.NewSource(1))
+	random := func() *RGBColor {
+		return &RGBColor{R: uint8(rnd.Intn(256)), G: uint8(rnd.Intn(256)), B: uint8(rnd.Intn(256))}
+	}
+
+	var colors []NamedColor
+
+	for i := 0; i < 300; i++ {
+		colors = append(colors, NamedColor{Name: string(rune('a' + i)), Color: random()})
+	}
+
+	p := NewPalette(colors...)
+
+	var wg sync.WaitGroup
+
+	for g := 0; g < 4; g++ {
+
+		targets := make([]*RGBColor, 50)
+		for i := range targets {
+			targets[i] = random()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for _, target := range targets {
+
+				best := math.Inf(1)
+				for _, c := range colors {
+					if d := DistanceOKLab.Distance(target, c.Color); d < best {
+						best = d
+					}
+				}
+
+				m, _ := p.Nearest(target)
+				if math.Abs(m.Distance-best) > 1e-12 {
+					t.Errorf("nearest distance %v does not equal linear scan %v", m.Distance, best)
+				}
+
+				k := p.KNearest(target, 5)
+				for i := 1; i < len(k); i++ {
+					if k[i].Distance < k[i-1].Distance {
+						t.Error("KNearest results not ordered by distance")
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,306 @@
+package colors
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Palette file formats
+const (
+	FormatGPL      = "GIMP"
+	FormatJASC     = "JASC-PAL"
+	FormatPaintNET = "Paint.NET"
+)
+
+const (
+	// jascMaxColors limits the color count read from a JASC palette's header
+	jascMaxColors = 1 << 16
+
+	// jascPreallocColors limits the colors allocated up front, before they are read
+	jascPreallocColors = 256
+)
+
+// PaletteFileError describes a malformed palette file
+type PaletteFileError struct {
+	// Format is the palette file format being read, e.g. FormatGPL
+	Format string
+
+	// Line is the 1 based line number of the malformed line, 0 when the error is not specific to a line
+	Line int
+
+	// Text is the malformed line
+	Text string
+
+	// Reason describes what is wrong with the line
+	Reason string
+}
+
+// Error returns the PaletteFileError's message
+func (e *PaletteFileError) Error() string {
+
+	if e.Line == 0 {
+		return fmt.Sprintf("colors: malformed %s palette: %s", e.Format, e.Reason)
+	}
+
+	return fmt.Sprintf("colors: malformed %s palette at line %d: %s: %q", e.Format, e.Line, e.Reason, e.Text)
+}
+
+// paletteLines iterates over the lines of a palette file with any trailing carriage returns removed
+type paletteLines struct {
+	scanner *bufio.Scanner
+	format  string
+	line    int
+	text    string
+}
+
+func newPaletteLines(r io.Reader, format string) *paletteLines {
+	return &paletteLines{scanner: bufio.NewScanner(r), format: format}
+}
+
+func (l *paletteLines) next() bool {
+
+	if !l.scanner.Scan() {
+		return false
+	}
+
+	l.line++
+	l.text = strings.TrimRight(l.scanner.Text(), "\r")
+
+	return true
+}
+
+// errorf returns a PaletteFileError for the current line
+func (l *paletteLines) errorf(format string, args ...interface{}) error {
+	return &PaletteFileError{Format: l.format, Line: l.line, Text: l.text, Reason: fmt.Sprintf(format, args...)}
+}
+
+// parseChannels parses three whitespace separated 0-255 channels, returning any remaining text
+func (l *paletteLines) parseChannels(text string) (*RGBColor, string, error) {
+
+	var ch [3]uint8
+
+	for i := range ch {
+
+		text = strings.TrimLeft(text, " \t")
+		end := strings.IndexAny(text, " \t")
+
+		if end == -1 {
+			end = len(text)
+		}
+
+		if end == 0 {
+			return nil, "", l.errorf("expected 3 color channels")
+		}
+
+		v, err := strconv.ParseUint(text[:end], 10, 8)
+		if err != nil {
+			return nil, "", l.errorf("invalid color channel %q", text[:end])
+		}
+
+		ch[i] = uint8(v)
+		text = text[end:]
+	}
+
+	return &RGBColor{R: ch[0], G: ch[1], B: ch[2]}, strings.TrimSpace(text), nil
+}
+
+// ReadGPL reads a GIMP .gpl palette, returning its name and colors
+func ReadGPL(r io.Reader) (name string, colors []NamedColor, err error) {
+
+	lines := newPaletteLines(r, FormatGPL)
+
+	if !lines.next() || strings.TrimSpace(lines.text) != "GIMP Palette" {
+		if err = lines.scanner.Err(); err != nil {
+			return "", nil, err
+		}
+		return "", nil, &PaletteFileError{Format: FormatGPL, Line: lines.line, Text: lines.text, Reason: "missing GIMP Palette header"}
+	}
+
+	for lines.next() {
+
+		text := strings.TrimSpace(lines.text)
+
+		switch {
+		case text == "" || text[0] == '#':
+			continue
+		case strings.HasPrefix(text, "Name:"):
+			name = strings.TrimSpace(text[len("Name:"):])
+			continue
+		case strings.HasPrefix(text, "Columns:"):
+			continue
+		}
+
+		rgb, colorName, err := lines.parseChannels(text)
+		if err != nil {
+			return "", nil, err
+		}
+
+		colors = append(colors, NamedColor{Name: colorName, Color: rgb})
+	}
+
+	if err = lines.scanner.Err(); err != nil {
+		return "", nil, err
+	}
+
+	return name, colors, nil
+}
+
+// WriteGPL writes the colors as a GIMP .gpl palette with the given name, alpha is discarded
+func WriteGPL(w io.Writer, name string, colors []NamedColor) error {
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "GIMP Palette\nName: %s\n#\n", name)
+
+	for _, c := range colors {
+
+		rgb := c.Color.ToRGB()
+
+		if c.Name == "" {
+			fmt.Fprintf(bw, "%3d %3d %3d\n", rgb.R, rgb.G, rgb.B)
+		} else {
+			fmt.Fprintf(bw, "%3d %3d %3d\t%s\n", rgb.R, rgb.G, rgb.B, c.Name)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ReadJASC reads a JASC .pal palette as used by Paint Shop Pro, JASC palettes do not name their colors
+func ReadJASC(r io.Reader) ([]NamedColor, error) {
+
+	lines := newPaletteLines(r, FormatJASC)
+	header := []string{"JASC-PAL", "0100"}
+
+	for _, expected := range header {
+		if !lines.next() || strings.TrimSpace(lines.text) != expected {
+			if err := lines.scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, &PaletteFileError{Format: FormatJASC, Line: lines.line, Text: lines.text, Reason: "expected " + expected}
+		}
+	}
+
+	if !lines.next() {
+		if err := lines.scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, &PaletteFileError{Format: FormatJASC, Reason: "missing color count"}
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(lines.text))
+	if err != nil || count < 0 {
+		return nil, lines.errorf("invalid color count")
+	}
+
+	if count > jascMaxColors {
+		return nil, lines.errorf("color count exceeds %d", jascMaxColors)
+	}
+
+	capacity := count
+	if capacity > jascPreallocColors {
+		capacity = jascPreallocColors
+	}
+
+	colors := make([]NamedColor, 0, capacity)
+
+	for len(colors) < count && lines.next() {
+
+		text := strings.TrimSpace(lines.text)
+
+		rgb, rest, err := lines.parseChannels(text)
+		if err != nil {
+			return nil, err
+		}
+
+		if rest != "" {
+			return nil, lines.errorf("unexpected text after color channels")
+		}
+
+		colors = append(colors, NamedColor{Color: rgb})
+	}
+
+	if err := lines.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(colors) != count {
+		return nil, &PaletteFileError{Format: FormatJASC, Reason: fmt.Sprintf("expected %d colors, found %d", count, len(colors))}
+	}
+
+	return colors, nil
+}
+
+// WriteJASC writes the colors as a JASC .pal palette, names and alpha are discarded
+func WriteJASC(w io.Writer, colors []NamedColor) error {
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "JASC-PAL\r\n0100\r\n%d\r\n", len(colors))
+
+	for _, c := range colors {
+		rgb := c.Color.ToRGB()
+		fmt.Fprintf(bw, "%d %d %d\r\n", rgb.R, rgb.G, rgb.B)
+	}
+
+	return bw.Flush()
+}
+
+// ReadPaintNET reads a Paint.NET .txt palette of AARRGGBB hex lines, Paint.NET palettes
+// do not name their colors. Colors are returned as RGBAColors
+func ReadPaintNET(r io.Reader) ([]NamedColor, error) {
+
+	lines := newPaletteLines(r, FormatPaintNET)
+
+	var colors []NamedColor
+
+	for lines.next() {
+
+		text := strings.TrimSpace(lines.text)
+
+		if text == "" || text[0] == ';' {
+			continue
+		}
+
+		if len(text) != 8 {
+			return nil, lines.errorf("expected 8 hex digits AARRGGBB")
+		}
+
+		v, err := strconv.ParseUint(text, 16, 32)
+		if err != nil {
+			return nil, lines.errorf("invalid hex digits")
+		}
+
+		colors = append(colors, NamedColor{Color: &RGBAColor{
+			R: uint8(v >> 16),
+			G: uint8(v >> 8),
+			B: uint8(v),
+			A: float64(uint8(v>>24)) / 255,
+		}})
+	}
+
+	if err := lines.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return colors, nil
+}
+
+// WritePaintNET writes the colors as a Paint.NET .txt palette, names are discarded
+func WritePaintNET(w io.Writer, colors []NamedColor) error {
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprint(bw, "; paint.net Palette File\n")
+
+	for _, c := range colors {
+		rgba := c.Color.ToRGBA()
+		fmt.Fprintf(bw, "%02X%02X%02X%02X\n", uint8(math.Floor(rgba.A*255+.5)), rgba.R, rgba.G, rgba.B)
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,95 @@
+package colors
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGPL(t *testing.T) {
+
+	src := "GIMP Palette\r\nName: Brand\r\nColumns: 4\r\n# comment\r\n\r\n255   0   0\tBrand Red\r\n  0 128 255\r\n"
+
+	name, colors, err := ReadGPL(strings.NewReader(src))
+	Equal(t, err, nil)
+	Equal(t, name, "Brand")
+	Equal(t, len(colors), 2)
+	Equal(t, colors[0].Name, "Brand Red")
+	Equal(t, colors[0].Color.String(), "rgb(255,0,0)")
+	Equal(t, colors[1].Name, "")
+	Equal(t, colors[1].Color.String(), "rgb(0,128,255)")
+
+	var buf bytes.Buffer
+	Equal(t, WriteGPL(&buf, "Brand", colors), nil)
+	Equal(t, buf.String(), "GIMP Palette\nName: Brand\n#\n255   0   0\tBrand Red\n  0 128 255\n")
+
+	name, again, err := ReadGPL(&buf)
+	Equal(t, err, nil)
+	Equal(t, name, "Brand")
+	Equal(t, again, colors)
+
+	_, _, err = ReadGPL(strings.NewReader("GIMP Palette\n1 2 300 Bad\n"))
+	var perr *PaletteFileError
+	Equal(t, errors.As(err, &perr), true)
+	Equal(t, perr.Format, FormatGPL)
+	Equal(t, perr.Line, 2)
+	Equal(t, perr.Text, "1 2 300 Bad")
+	Equal(t, err.Error(), `colors: malformed GIMP palette at line 2: invalid color channel "300": "1 2 300 Bad"`)
+
+	_, _, err = ReadGPL(strings.NewReader("1 2 3\n"))
+	Equal(t, errors.As(err, &perr), true)
+	Equal(t, perr.Reason, "missing GIMP Palette header")
+
+	_, _, err = ReadGPL(strings.NewReader("GIMP Palette\n1 2\n"))
+	Equal(t, err.Error(), `colors: malformed GIMP palette at line 2: expected 3 color channels: "1 2"`)
+}
+
+func TestJASC(t *testing.T) {
+
+	colors, err := ReadJASC(strings.NewReader("JASC-PAL\r\n0100\r\n2\r\n255 0 0\r\n0 128 255\r\n"))
+	Equal(t, err, nil)
+	Equal(t, len(colors), 2)
+	Equal(t, colors[1].Color.String(), "rgb(0,128,255)")
+
+	var buf bytes.Buffer
+	Equal(t, WriteJASC(&buf, colors), nil)
+	Equal(t, buf.String(), "JASC-PAL\r\n0100\r\n2\r\n255 0 0\r\n0 128 255\r\n")
+
+	_, err = ReadJASC(strings.NewReader("JASC-PAL\n0100\n3\n255 0 0\n"))
+	Equal(t, err.Error(), "colors: malformed JASC-PAL palette: expected 3 colors, found 1")
+
+	_, err = ReadJASC(strings.NewReader("JASC-PAL\n0200\n"))
+	Equal(t, err.Error(), `colors: malformed JASC-PAL palette at line 2: expected 0100: "0200"`)
+
+	_, err = ReadJASC(strings.NewReader("JASC-PAL\n0100\n1\n1 2 3 4\n"))
+	Equal(t, err.Error(), `colors: malformed JASC-PAL palette at line 4: unexpected text after color channels: "1 2 3 4"`)
+
+	_, err = ReadJASC(strings.NewReader("JASC-PAL\n0100\n9223372036854775807\n"))
+	Equal(t, err.Error(), `colors: malformed JASC-PAL palette at line 3: color count exceeds 65536: "9223372036854775807"`)
+
+	_, err = ReadJASC(strings.NewReader("JASC-PAL\n0100\n65536\n255 0 0\n"))
+	Equal(t, err.Error(), "colors: malformed JASC-PAL palette: expected 65536 colors, found 1")
+}
+
+func TestPaintNET(t *testing.T) {
+
+	colors, err := ReadPaintNET(strings.NewReader("; paint.net Palette File\n;comment\nFFFF0000\n800080FF\n"))
+	Equal(t, err, nil)
+	Equal(t, len(colors), 2)
+	Equal(t, colors[0].Color.String(), "rgba(255,0,0,1)")
+	Equal(t, colors[1].Color.ToRGB().String(), "rgb(0,128,255)")
+
+	hex, _ := ParseHEX("#abc")
+	colors = append(colors, NamedColor{Name: "ignored", Color: hex})
+
+	var buf bytes.Buffer
+	Equal(t, WritePaintNET(&buf, colors), nil)
+	Equal(t, buf.String(), "; paint.net Palette File\nFFFF0000\n800080FF\nFFAABBCC\n")
+
+	_, err = ReadPaintNET(strings.NewReader("FF0000\n"))
+	Equal(t, err.Error(), `colors: malformed Paint.NET palette at line 1: expected 8 hex digits AARRGGBB: "FF0000"`)
+
+	_, err = ReadPaintNET(strings.NewReader("FF00000G\n"))
+	Equal(t, err.Error(), `colors: malformed Paint.NET palette at line 1: invalid hex digits: "FF00000G"`)
+}
@@ -0,0 +1,207 @@
+package colors
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+)
+
+var (
+	// ErrBadPalette is returned when a palette is empty or too large to be used
+	ErrBadPalette = errors.New("palette must contain between 1 and 256 colors")
+)
+
+// Dither is the dithering method used when quantizing an image
+type Dither uint8
+
+// Dithering methods
+const (
+	// NoDither maps every pixel to its nearest palette color
+	NoDither Dither = iota
+
+	// FloydSteinberg diffuses the quantization error to four neighbouring pixels
+	FloydSteinberg
+
+	// Atkinson diffuses three quarters of the quantization error to six neighbouring pixels
+	Atkinson
+
+	// Sierra diffuses the quantization error to ten neighbouring pixels across three rows
+	Sierra
+
+	// Bayer applies an 8x8 ordered dithering threshold matrix
+	Bayer
+)
+
+// QuantizeOptions contains the options used when quantizing an image
+type QuantizeOptions struct {
+	// Metric is the distance metric used to find the nearest palette color, defaults to DistanceRGB
+	Metric DistanceMetric
+
+	// Dither is the dithering method, defaults to NoDither
+	Dither Dither
+}
+
+// diffusion is a single entry of an error diffusion kernel
+type diffusion struct {
+	dx, dy int
+	weight float64
+}
+
+var (
+	floydSteinbergKernel = []diffusion{
+		{1, 0, 7.0 / 16}, {-1, 1, 3.0 / 16}, {0, 1, 5.0 / 16}, {1, 1, 1.0 / 16},
+	}
+	atkinsonKernel = []diffusion{
+		{1, 0, 1.0 / 8}, {2, 0, 1.0 / 8},
+		{-1, 1, 1.0 / 8}, {0, 1, 1.0 / 8}, {1, 1, 1.0 / 8},
+		{0, 2, 1.0 / 8},
+	}
+	sierraKernel = []diffusion{
+		{1, 0, 5.0 / 32}, {2, 0, 3.0 / 32},
+		{-2, 1, 2.0 / 32}, {-1, 1, 4.0 / 32}, {0, 1, 5.0 / 32}, {1, 1, 4.0 / 32}, {2, 1, 2.0 / 32},
+		{-1, 2, 2.0 / 32}, {0, 2, 3.0 / 32}, {1, 2, 2.0 / 32},
+	}
+	bayerMatrix = [8][8]float64{
+		{0, 32, 8, 40, 2, 34, 10, 42},
+		{48, 16, 56, 24, 50, 18, 58, 26},
+		{12, 44, 4, 36, 14, 46, 6, 38},
+		{60, 28, 52, 20, 62, 30, 54, 22},
+		{3, 35, 11, 43, 1, 33, 9, 41},
+		{51, 19, 59, 27, 49, 17, 57, 25},
+		{15, 47, 7, 39, 13, 45, 5, 37},
+		{63, 31, 55, 23, 61, 29, 53, 21},
+	}
+)
+
+// quantizer finds the nearest palette entry for a color, caching previous lookups
+type quantizer struct {
+	metric DistanceMetric
+	rgb    [][3]float64
+	points [][3]float64
+	cache  map[[3]uint8]uint8
+}
+
+func newQuantizer(palette []Color, metric DistanceMetric) *quantizer {
+
+	q := &quantizer{
+		metric: metric,
+		rgb:    make([][3]float64, len(palette)),
+		points: make([][3]float64, len(palette)),
+		cache:  make(map[[3]uint8]uint8),
+	}
+
+	for i, c := range palette {
+		rgb := c.ToRGB()
+		q.rgb[i] = [3]float64{float64(rgb.R), float64(rgb.G), float64(rgb.B)}
+		q.points[i] = metric.point(q.rgb[i][0], q.rgb[i][1], q.rgb[i][2])
+	}
+
+	return q
+}
+
+// nearest returns the index of the palette entry nearest to the 8-bit sRGB color
+func (q *quantizer) nearest(key [3]uint8) uint8 {
+
+	if idx, ok := q.cache[key]; ok {
+		return idx
+	}
+
+	p := q.metric.point(float64(key[0]), float64(key[1]), float64(key[2]))
+	best, bestDist := 0, math.Inf(1)
+
+	for i, pt := range q.points {
+		if d := q.metric.between(p, pt); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+
+	q.cache[key] = uint8(best)
+
+	return uint8(best)
+}
+
+// Quantize maps every pixel of img onto the provided palette, optionally dithering, and
+// returns the result as an *image.Paletted; pixel alpha is ignored when matching colors
+func Quantize(img image.Image, palette []Color, opts QuantizeOptions) (*image.Paletted, error) {
+
+	if len(palette) == 0 || len(palette) > 256 {
+		return nil, ErrBadPalette
+	}
+
+	pal := make(color.Palette, len(palette))
+
+	for i, c := range palette {
+		rgba := c.ToRGBA()
+		pal[i] = color.NRGBA{R: rgba.R, G: rgba.G, B: rgba.B, A: uint8(rgba.A*255 + .5)}
+	}
+
+	bounds := img.Bounds()
+	dst := image.NewPaletted(bounds, pal)
+	q := newQuantizer(palette, opts.Metric)
+	w, h := bounds.Dx(), bounds.Dy()
+
+	pixels := make([][3]float64, w*h)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			px := color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+			pixels[y*w+x] = [3]float64{float64(px.R), float64(px.G), float64(px.B)}
+		}
+	}
+
+	var kernel []diffusion
+
+	switch opts.Dither {
+	case FloydSteinberg:
+		kernel = floydSteinbergKernel
+	case Atkinson:
+		kernel = atkinsonKernel
+	case Sierra:
+		kernel = sierraKernel
+	}
+
+	// ordered dithering offsets each pixel by roughly the spacing between palette colors
+	spread := 255 / math.Cbrt(float64(len(palette)))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+
+			px := pixels[y*w+x]
+
+			if opts.Dither == Bayer {
+				offset := ((bayerMatrix[y%8][x%8]+0.5)/64 - 0.5) * spread
+				px = [3]float64{px[0] + offset, px[1] + offset, px[2] + offset}
+			}
+
+			key := [3]uint8{clampUint8(px[0] / 255), clampUint8(px[1] / 255), clampUint8(px[2] / 255)}
+			idx := q.nearest(key)
+			dst.Pix[y*dst.Stride+x] = idx
+
+			if kernel == nil {
+				continue
+			}
+
+			chosen := q.rgb[idx]
+			errR := float64(key[0]) - chosen[0]
+			errG := float64(key[1]) - chosen[1]
+			errB := float64(key[2]) - chosen[2]
+
+			for _, k := range kernel {
+
+				nx, ny := x+k.dx, y+k.dy
+
+				if nx < 0 || nx >= w || ny >= h {
+					continue
+				}
+
+				n := &pixels[ny*w+nx]
+				n[0] += errR * k.weight
+				n[1] += errG * k.weight
+				n[2] += errB * k.weight
+			}
+		}
+	}
+
+	return dst, nil
+}
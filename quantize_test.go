@@ -0,0 +1,54 @@
+package colors
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestQuantize(t *testing.T) {
+
+	black, _ := ParseHEX("#000")
+	white, _ := ParseHEX("#fff")
+	palette := []Color{black, white}
+
+	img := image.NewGray(image.Rect(0, 0, 16, 16))
+
+	for i := range img.Pix {
+		img.Pix[i] = 128
+	}
+
+	count := func(p *image.Paletted) (n int) {
+		for _, idx := range p.Pix {
+			if idx == 1 {
+				n++
+			}
+		}
+		return
+	}
+
+	p, err := Quantize(img, palette, QuantizeOptions{})
+	Equal(t, err, nil)
+	Equal(t, len(p.Palette), 2)
+	Equal(t, p.Palette[1], color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+	Equal(t, count(p), 256)
+
+	for _, dither := range []Dither{FloydSteinberg, Atkinson, Sierra, Bayer} {
+		for _, metric := range []DistanceMetric{DistanceRGB, DistanceOKLab, DistanceCIEDE2000} {
+
+			p, err = Quantize(img, palette, QuantizeOptions{Metric: metric, Dither: dither})
+			Equal(t, err, nil)
+
+			n := count(p)
+			Equal(t, n > 64 && n < 192, true)
+		}
+	}
+
+	p, err = Quantize(img, palette, QuantizeOptions{Dither: Bayer})
+	Equal(t, err, nil)
+	Equal(t, count(p), 128)
+
+	p, err = Quantize(img, nil, QuantizeOptions{})
+	Equal(t, err, ErrBadPalette)
+	Equal(t, p, nil)
+}
@@ -0,0 +1,225 @@
+package colors
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	// goldenRatioConjugate is the fraction of a turn the hue advances by in a golden ratio walk,
+	// successive hues never repeating and always falling in the largest gap left by the last
+	goldenRatioConjugate = 0.6180339887498949
+
+	// randomAttempts is the number of samples drawn before giving up on finding one meeting
+	// the constraints and returning the closest color that does
+	randomAttempts = 1000
+
+	// oklchMaxChroma is the largest OKLCH chroma of any sRGB color, bounding the cylinder
+	// OKLCH colors are drawn from
+	oklchMaxChroma = .33
+)
+
+// RandomSpace is the color space random colors are drawn uniformly from
+type RandomSpace uint8
+
+// Random color spaces
+const (
+	// RandomSRGB draws colors uniformly from the sRGB cube
+	RandomSRGB RandomSpace = iota
+
+	// RandomOKLCH draws colors uniformly from the volume of the OKLCH cylinder lying within sRGB,
+	// spreading them evenly by perceived color
+	RandomOKLCH
+
+	// RandomHSL draws the hue, saturation and lightness of colors uniformly
+	RandomHSL
+)
+
+// RandomOptions contains the constraints of random colors
+type RandomOptions struct {
+	// Space is the color space colors are drawn uniformly from, defaults to RandomSRGB
+	Space RandomSpace
+
+	// Hues are the ranges of hues colors are drawn from, OKLCH hues except for RandomHSL
+	// which uses HSL hues. nil allows every hue
+	Hues []HueRange
+
+	// MinLightness and MaxLightness bound the lightness in [0, 1], OKLCH lightness except for
+	// RandomHSL which uses HSL lightness. Both 0 allow any lightness
+	MinLightness, MaxLightness float64
+
+	// MinAlpha and MaxAlpha bound the alpha in [0, 1], both 0 returning opaque colors
+	MinAlpha, MaxAlpha float64
+
+	// GoldenRatio walks the hue around the wheel by the golden ratio from one color to the next
+	// rather than drawing it at random, so that a sequence of colors is evenly spread by hue.
+	// RandomSRGB walks OKLCH hues, the sRGB cube having no hue axis
+	GoldenRatio bool
+}
+
+// RandomSource is a source of random numbers, such as a *math/rand.Rand. A math/rand/v2
+// generator may be used through an adapter, see the package documentation
+type RandomSource interface {
+	// Float64 returns a number in [0, 1)
+	Float64() float64
+
+	// Intn returns a number in [0, n)
+	Intn(n int) int
+}
+
+// Random generates random colors meeting constraints
+type Random struct {
+	rng  RandomSource
+	opts RandomOptions
+
+	// walk is the position of the golden ratio walk through the hue ranges, in [0, 1)
+	walk    float64
+	walking bool
+}
+
+// NewRandom returns a Random drawing colors using rng, so that seeding rng makes the colors
+// reproducible. A nil rng uses a *math/rand.Rand seeded from the current time
+func NewRandom(rng RandomSource, opts RandomOptions) *Random {
+
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	return &Random{rng: rng, opts: opts}
+}
+
+// Colors returns the next n random colors
+func (r *Random) Colors(n int) []Color {
+
+	colors := make([]Color, n)
+
+	for i := range colors {
+		colors[i] = r.Color()
+	}
+
+	return colors
+}
+
+// Color returns the next random color, an RGBColor or an RGBAColor when the alpha constraints
+// allow translucent colors, alpha being rounded to 3 decimals
+func (r *Random) Color() Color {
+
+	var rgb *RGBColor
+
+	switch {
+	case r.opts.Space == RandomHSL:
+		rgb = r.hsl()
+	case r.opts.Space == RandomSRGB && !r.opts.GoldenRatio:
+		rgb = r.srgb()
+	default:
+		rgb = r.oklch()
+	}
+
+	if r.opts.MinAlpha == 0 && r.opts.MaxAlpha == 0 {
+		return rgb
+	}
+
+	// round alpha as CSS and the string forms of colors would
+	a := math.Round(r.between(r.opts.MinAlpha, r.opts.MaxAlpha)*1000) / 1000
+	if a >= 1 {
+		return rgb
+	}
+
+	return &RGBAColor{R: rgb.R, G: rgb.G, B: rgb.B, A: a}
+}
+
+// hue returns the hue of the next color, taking the next step of the walk or drawing it at random
+func (r *Random) hue() func() float64 {
+
+	hues := hueRanges(r.opts.Hues)
+
+	if !r.opts.GoldenRatio {
+		return func() float64 {
+			return hues.at(r.rng.Float64())
+		}
+	}
+
+	if r.walking {
+		r.walk = math.Mod(r.walk+goldenRatioConjugate, 1)
+	} else {
+		r.walk, r.walking = r.rng.Float64(), true
+	}
+
+	h := hues.at(r.walk)
+
+	return func() float64 {
+		return h
+	}
+}
+
+// lightness returns a lightness drawn uniformly from the lightness constraints
+func (r *Random) lightness() float64 {
+
+	if r.opts.MinLightness == 0 && r.opts.MaxLightness == 0 {
+		return r.rng.Float64()
+	}
+
+	return r.between(r.opts.MinLightness, r.opts.MaxLightness)
+}
+
+func (r *Random) between(lo, hi float64) float64 {
+	return lo + (hi-lo)*r.rng.Float64()
+}
+
+func (r *Random) hsl() *RGBColor {
+
+	red, green, blue := hslToRGB(r.hue()(), r.rng.Float64(), r.lightness())
+
+	return &RGBColor{R: clampUint8(red), G: clampUint8(green), B: clampUint8(blue)}
+}
+
+// srgb draws colors from the sRGB cube until one meets the hue and lightness constraints
+func (r *Random) srgb() *RGBColor {
+
+	hues := hueRanges(r.opts.Hues)
+	constrained := len(hues) > 0 || r.opts.MinLightness != 0 || r.opts.MaxLightness != 0
+
+	for attempt := 0; attempt < randomAttempts; attempt++ {
+
+		rgb := &RGBColor{R: uint8(r.rng.Intn(256)), G: uint8(r.rng.Intn(256)), B: uint8(r.rng.Intn(256))}
+		if !constrained {
+			return rgb
+		}
+
+		l, ch, h := rgbToOKLab(rgb.R, rgb.G, rgb.B).lch()
+
+		lightnessOK := r.opts.MinLightness == 0 && r.opts.MaxLightness == 0 ||
+			l >= r.opts.MinLightness && l <= r.opts.MaxLightness
+
+		// grays have no hue to constrain
+		hueOK := len(hues) == 0 || ch > 1e-4 && hues.contains(h)
+
+		if lightnessOK && hueOK {
+			return rgb
+		}
+	}
+
+	return r.oklch()
+}
+
+// oklch draws colors from the OKLCH cylinder until one lies within sRGB, reducing the chroma
+// of the last drawn when none does
+func (r *Random) oklch() *RGBColor {
+
+	hue := r.hue()
+
+	var c oklab
+
+	for attempt := 0; attempt < randomAttempts; attempt++ {
+
+		// the square root spreads chroma evenly over the area of the cylinder's cross section
+		c = fromLCH(r.lightness(), oklchMaxChroma*math.Sqrt(r.rng.Float64()), hue())
+
+		if c.inGamut() {
+			return c.toRGB()
+		}
+	}
+
+	return c.toGamutRGB()
+}
@@ -0,0 +1,90 @@
+package colors
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRandom(t *testing.T) {
+
+	for _, space := range []RandomSpace{RandomSRGB, RandomOKLCH, RandomHSL} {
+
+		colors := NewRandom(rand.New(rand.NewSource(1)), RandomOptions{Space: space}).Colors(20)
+		Equal(t, len(colors), 20)
+
+		// the same seed draws the same colors
+		Equal(t, NewRandom(rand.New(rand.NewSource(1)), RandomOptions{Space: space}).Colors(20), colors)
+		NotEqual(t, NewRandom(rand.New(rand.NewSource(2)), RandomOptions{Space: space}).Colors(20), colors)
+
+		for _, c := range colors {
+			_, ok := c.(*RGBColor)
+			Equal(t, ok, true)
+		}
+	}
+
+	Equal(t, len(NewRandom(nil, RandomOptions{}).Colors(3)), 3)
+
+	// any RandomSource may draw the colors
+	Equal(t, NewRandom(fixedSource{}, RandomOptions{}).Color(), Color(&RGBColor{R: 128, G: 128, B: 128}))
+}
+
+// fixedSource is a RandomSource always returning the middle of its range
+type fixedSource struct{}
+
+func (fixedSource) Float64() float64 { return .5 }
+
+func (fixedSource) Intn(n int) int { return n / 2 }
+
+func TestRandomConstraints(t *testing.T) {
+
+	opts := RandomOptions{Hues: []HueRange{{From: 330, To: 30}}, MinLightness: .4, MaxLightness: .6}
+
+	for _, space := range []RandomSpace{RandomSRGB, RandomOKLCH} {
+
+		opts.Space = space
+
+		for _, c := range NewRandom(rand.New(rand.NewSource(1)), opts).Colors(50) {
+			rgb := c.ToRGB()
+			l, _, h := rgbToOKLab(rgb.R, rgb.G, rgb.B).lch()
+			Equal(t, l > .39 && l < .61, true)
+			Equal(t, h > 329 || h < 31, true)
+		}
+	}
+
+	opts.Space = RandomHSL
+
+	for _, c := range NewRandom(rand.New(rand.NewSource(1)), opts).Colors(50) {
+		rgb := c.ToRGB()
+		h, s, l := rgbToHSL(rgb.R, rgb.G, rgb.B)
+		Equal(t, l > .39 && l < .61, true)
+
+		// rounding to 8-bit channels moves the hue of nearly gray colors
+		Equal(t, h > 329 || h < 31 || s < .1, true)
+	}
+
+	for _, c := range NewRandom(rand.New(rand.NewSource(1)), RandomOptions{MinAlpha: .2, MaxAlpha: .5}).Colors(50) {
+		rgba, ok := c.(*RGBAColor)
+		Equal(t, ok, true)
+		Equal(t, rgba.A >= .2 && rgba.A <= .5, true)
+	}
+}
+
+func TestRandomGoldenRatio(t *testing.T) {
+
+	colors := NewRandom(rand.New(rand.NewSource(1)), RandomOptions{Space: RandomOKLCH, GoldenRatio: true, MinLightness: .7, MaxLightness: .7}).Colors(8)
+
+	hues := make([]float64, len(colors))
+	for i, c := range colors {
+		rgb := c.ToRGB()
+		_, _, hues[i] = rgbToOKLab(rgb.R, rgb.G, rgb.B).lch()
+	}
+
+	// each hue is a golden ratio of a turn on from the last, allowing for gamut mapping and rounding
+	for i := 1; i < len(hues); i++ {
+		step := hues[i] - hues[i-1]
+		if step < 0 {
+			step += 360
+		}
+		Equal(t, step > 218 && step < 227, true)
+	}
+}
@@ -0,0 +1,550 @@
+package colors
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+var (
+	// ErrNoColors is returned when rendering an empty list of colors
+	ErrNoColors = errors.New("colors: no colors to render")
+
+	// ErrImageTooLarge is returned, wrapped with the requested size, when rendering more than
+	// 65536 colors or an image of more than the Renderer's MaxPixels
+	ErrImageTooLarge = errors.New("colors: image too large")
+)
+
+// Layout is the arrangement of rendered swatches
+type Layout uint8
+
+// Layouts
+const (
+	// LayoutStrip renders swatches as a single row, and gradients as a horizontal bar
+	LayoutStrip Layout = iota
+
+	// LayoutGrid renders swatches as rows of Columns swatches, and gradients as a horizontal bar
+	LayoutGrid
+
+	// LayoutWheel renders swatches as equal wedges of a circle clockwise from the top,
+	// and gradients as a conic gradient returning to the first stop
+	LayoutWheel
+)
+
+// Label selects the text drawn on rendered swatches
+type Label uint8
+
+// Labels, which may be combined, e.g. LabelHex | LabelContrast
+const (
+	// LabelHex labels swatches with their hex code, including the alpha of translucent colors
+	LabelHex Label = 1 << iota
+
+	// LabelContrast labels swatches with their WCAG contrast ratio against the Renderer's
+	// ContrastWith color, or against the label's own black or white text when it is nil
+	LabelContrast
+)
+
+// Default rendering sizes
+const (
+	defaultSwatchSize = 64
+
+	// gradientStripLength is the length of a gradient strip in swatch sizes
+	gradientStripLength = 8
+
+	// wheelDiameter is the diameter of a wheel in swatch sizes
+	wheelDiameter = 4
+
+	defaultMaxPixels = 64 << 20
+
+	// maxRenderColors is the largest number of swatches or gradient stops rendered
+	maxRenderColors = 1 << 16
+)
+
+var (
+	checkerLight = RGBColor{R: 255, G: 255, B: 255}
+	checkerDark  = RGBColor{R: 204, G: 204, B: 204}
+)
+
+// Renderer renders colors and gradients as PNG or SVG swatch images, its zero value
+// rendering an unlabeled strip of 64 pixel swatches on a transparent background.
+// Translucent colors are drawn over a checkerboard
+type Renderer struct {
+	// Layout is the arrangement of swatches
+	Layout Layout
+
+	// Size is the width and height of each swatch in pixels, 0 using 64. Gradient strips are
+	// 8 swatches long and wheels are 4 swatches in diameter
+	Size int
+
+	// Columns is the number of swatches per row of LayoutGrid, 0 or less using a near square grid
+	Columns int
+
+	// Gap is the space in pixels between the swatches of strips and grids, negative values using 0
+	Gap int
+
+	// Labels selects the text drawn on each swatch, or on each stop of a gradient
+	Labels Label
+
+	// ContrastWith is the color contrast ratios of LabelContrast are measured against
+	ContrastWith Color
+
+	// Background fills the image behind the swatches, nil being transparent
+	Background Color
+
+	// MaxPixels is the largest width × height of an image, 0 using 64 megapixels. SVG images
+	// are limited as well, as they are usually rasterized at their declared size
+	MaxPixels int
+}
+
+func (r Renderer) size() int {
+
+	if r.Size <= 0 {
+		return defaultSwatchSize
+	}
+
+	return r.Size
+}
+
+func (r Renderer) gap() int {
+
+	if r.Gap < 0 {
+		return 0
+	}
+
+	return r.Gap
+}
+
+func (r Renderer) maxPixels() int {
+
+	if r.MaxPixels <= 0 {
+		return defaultMaxPixels
+	}
+
+	return r.MaxPixels
+}
+
+// scale returns the pixel scale of label text
+func (r Renderer) scale() int {
+
+	if s := r.size() / defaultSwatchSize; s > 1 {
+		return s
+	}
+
+	return 1
+}
+
+// checkerSize returns the size of the checkerboard squares behind translucent colors
+func (r Renderer) checkerSize() int {
+
+	if s := r.size() / 8; s > 4 {
+		return s
+	}
+
+	return 4
+}
+
+// columns returns the number of swatches per row when rendering n swatches
+func (r Renderer) columns(n int) int {
+
+	switch {
+	case r.Layout == LayoutStrip:
+		return n
+	case r.Columns > 0 && r.Columns < n:
+		return r.Columns
+	case r.Columns > 0:
+		return n
+	}
+
+	return int(math.Ceil(math.Sqrt(float64(n))))
+}
+
+// Bounds returns the size of the image of n swatches, or of a gradient when n is 0
+func (r Renderer) Bounds(n int) image.Rectangle {
+
+	size := r.size()
+
+	if r.Layout == LayoutWheel {
+		return image.Rect(0, 0, size*wheelDiameter, size*wheelDiameter)
+	}
+
+	if n == 0 {
+		return image.Rect(0, 0, size*gradientStripLength, size)
+	}
+
+	cols := r.columns(n)
+	rows := (n + cols - 1) / cols
+	gap := r.gap()
+
+	return image.Rect(0, 0, cols*size+(cols-1)*gap, rows*size+(rows-1)*gap)
+}
+
+// validate returns ErrNoColors when there are no colors, or ErrImageTooLarge when there are more
+// than maxRenderColors or the image of n swatches, or of a gradient when n is 0, exceeds MaxPixels
+func (r Renderer) validate(colors, n int) error {
+
+	if colors == 0 {
+		return ErrNoColors
+	}
+
+	if colors > maxRenderColors {
+		return fmt.Errorf("%w: %d colors, at most %d", ErrImageTooLarge, colors, maxRenderColors)
+	}
+
+	limit := r.maxPixels()
+
+	// checked first so that the image's width and height cannot overflow
+	if r.size() > limit || r.gap() > limit {
+		return fmt.Errorf("%w: swatch size %d and gap %d, at most %d pixels", ErrImageTooLarge, r.size(), r.gap(), limit)
+	}
+
+	b := r.Bounds(n)
+
+	if b.Dx() > limit || b.Dy() > limit || b.Dx()*b.Dy() > limit {
+		return fmt.Errorf("%w: %dx%d, at most %d pixels", ErrImageTooLarge, b.Dx(), b.Dy(), limit)
+	}
+
+	return nil
+}
+
+// cell returns the rectangle of swatch i of a strip or grid of n swatches
+func (r Renderer) cell(i, n int) image.Rectangle {
+
+	size := r.size()
+	cols := r.columns(n)
+	x := (i % cols) * (size + r.gap())
+	y := (i / cols) * (size + r.gap())
+
+	return image.Rect(x, y, x+size, y+size)
+}
+
+// wheelAngle returns the clockwise angle from the top of the wheel of the point x, y, as a fraction of a turn
+func (r Renderer) wheelAngle(x, y float64) float64 {
+
+	radius := float64(r.size()*wheelDiameter) / 2
+	a := math.Atan2(x-radius, radius-y) / (2 * math.Pi)
+
+	if a < 0 {
+		a++
+	}
+
+	return a
+}
+
+// wheelPoint returns the point at the fraction of a turn clockwise from the top and the fraction of the radius
+func (r Renderer) wheelPoint(turn, radius float64) (x, y float64) {
+
+	c := float64(r.size()*wheelDiameter) / 2
+	a := turn * 2 * math.Pi
+
+	return c + math.Sin(a)*c*radius, c - math.Cos(a)*c*radius
+}
+
+// inWheel reports whether the pixel x, y lies within the wheel
+func (r Renderer) inWheel(x, y int) bool {
+
+	radius := float64(r.size()*wheelDiameter) / 2
+	dx := float64(x) + .5 - radius
+	dy := float64(y) + .5 - radius
+
+	return dx*dx+dy*dy <= radius*radius
+}
+
+// label returns the label lines of c and the black or white ink most readable on it
+func (r Renderer) label(c *RGBAColor) (lines []string, ink *RGBColor) {
+
+	var back *RGBColor = &checkerLight
+	if r.Background != nil {
+		back = r.Background.ToRGB()
+	}
+
+	backdrop := composite(c, back)
+	black, white := &RGBColor{}, &RGBColor{R: 255, G: 255, B: 255}
+
+	ink = black
+	if Contrast(white, backdrop) > Contrast(black, backdrop) {
+		ink = white
+	}
+
+	if r.Labels&LabelHex != 0 {
+		lines = append(lines, Formatter{HexAlpha: true}.ToString(c))
+	}
+
+	if r.Labels&LabelContrast != 0 {
+
+		var against Color = ink
+		if r.ContrastWith != nil {
+			against = r.ContrastWith
+		}
+
+		lines = append(lines, fmt.Sprintf("%.2f:1", Contrast(against, backdrop)))
+	}
+
+	return lines, ink
+}
+
+// gradient interpolates between stops in OKLab
+type gradient struct {
+	labs   []oklab
+	alphas []float64
+
+	// cyclic interpolates from the last stop back to the first
+	cyclic bool
+}
+
+func newGradient(stops []Color, cyclic bool) *gradient {
+
+	g := &gradient{cyclic: cyclic}
+
+	for _, s := range stops {
+		rgba := s.ToRGBA()
+		g.labs = append(g.labs, rgbToOKLab(rgba.R, rgba.G, rgba.B))
+		g.alphas = append(g.alphas, rgba.A)
+	}
+
+	return g
+}
+
+// position returns the position in [0, 1] of stop i
+func (g *gradient) position(i int) float64 {
+
+	switch {
+	case g.cyclic:
+		return float64(i) / float64(len(g.labs))
+	case len(g.labs) == 1:
+		return .5
+	}
+
+	return float64(i) / float64(len(g.labs)-1)
+}
+
+// at returns the color at t in [0, 1]
+func (g *gradient) at(t float64) *RGBAColor {
+
+	n := len(g.labs)
+	segments := n - 1
+
+	if g.cyclic {
+		segments = n
+	}
+
+	if segments == 0 {
+		return g.color(g.labs[0], g.alphas[0])
+	}
+
+	pos := math.Max(0, math.Min(1, t)) * float64(segments)
+	i := int(pos)
+
+	if i >= segments {
+		i = segments - 1
+	}
+
+	f := pos - float64(i)
+	j := (i + 1) % n
+	a, b := g.labs[i], g.labs[j]
+
+	return g.color(oklab{
+		L: a.L + (b.L-a.L)*f,
+		A: a.A + (b.A-a.A)*f,
+		B: a.B + (b.B-a.B)*f,
+	}, g.alphas[i]+(g.alphas[j]-g.alphas[i])*f)
+}
+
+func (g *gradient) color(lab oklab, alpha float64) *RGBAColor {
+	rgb := lab.toRGB()
+	return &RGBAColor{R: rgb.R, G: rgb.G, B: rgb.B, A: math.Round(alpha*1000) / 1000}
+}
+
+// Image renders the colors as swatches
+func (r Renderer) Image(colors []Color) (*image.NRGBA, error) {
+
+	if err := r.validate(len(colors), len(colors)); err != nil {
+		return nil, err
+	}
+
+	img := r.newImage(len(colors))
+	n := len(colors)
+
+	if r.Layout == LayoutWheel {
+
+		b := img.Rect
+
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if r.inWheel(x, y) {
+					i := int(r.wheelAngle(float64(x)+.5, float64(y)+.5) * float64(n))
+					if i >= n {
+						i = n - 1
+					}
+					r.paint(img, x, y, colors[i].ToRGBA())
+				}
+			}
+		}
+
+		for i, c := range colors {
+
+			radius := .6
+			if n == 1 {
+				radius = 0
+			}
+
+			x, y := r.wheelPoint((float64(i)+.5)/float64(n), radius)
+			r.drawLabel(img, int(x), int(y), true, c.ToRGBA())
+		}
+
+		return img, nil
+	}
+
+	for i, c := range colors {
+
+		rgba := c.ToRGBA()
+		cell := r.cell(i, n)
+
+		for y := cell.Min.Y; y < cell.Max.Y; y++ {
+			for x := cell.Min.X; x < cell.Max.X; x++ {
+				r.paint(img, x, y, rgba)
+			}
+		}
+
+		r.drawLabel(img, (cell.Min.X+cell.Max.X)/2, cell.Max.Y-4*r.scale(), false, rgba)
+	}
+
+	return img, nil
+}
+
+// GradientImage renders a gradient through the stops, interpolated in OKLab
+func (r Renderer) GradientImage(stops []Color) (*image.NRGBA, error) {
+
+	if err := r.validate(len(stops), 0); err != nil {
+		return nil, err
+	}
+
+	img := r.newImage(0)
+	b := img.Rect
+	g := newGradient(stops, r.Layout == LayoutWheel)
+
+	if r.Layout == LayoutWheel {
+
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if r.inWheel(x, y) {
+					r.paint(img, x, y, g.at(r.wheelAngle(float64(x)+.5, float64(y)+.5)))
+				}
+			}
+		}
+
+		for i, s := range stops {
+			x, y := r.wheelPoint(g.position(i), .6)
+			r.drawLabel(img, int(x), int(y), true, s.ToRGBA())
+		}
+
+		return img, nil
+	}
+
+	for x := b.Min.X; x < b.Max.X; x++ {
+
+		c := g.at((float64(x) + .5) / float64(b.Dx()))
+
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			r.paint(img, x, y, c)
+		}
+	}
+
+	for i, s := range stops {
+		r.drawLabel(img, int(g.position(i)*float64(b.Dx())), b.Max.Y-4*r.scale(), false, s.ToRGBA())
+	}
+
+	return img, nil
+}
+
+// WritePNG renders the colors as swatches and writes them as a PNG image
+func (r Renderer) WritePNG(w io.Writer, colors []Color) error {
+
+	img, err := r.Image(colors)
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(w, img)
+}
+
+// WriteGradientPNG renders a gradient through the stops and writes it as a PNG image
+func (r Renderer) WriteGradientPNG(w io.Writer, stops []Color) error {
+
+	img, err := r.GradientImage(stops)
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(w, img)
+}
+
+// newImage returns a new image for n swatches, or a gradient when n is 0, filled with the Background
+func (r Renderer) newImage(n int) *image.NRGBA {
+
+	img := image.NewNRGBA(r.Bounds(n))
+
+	if r.Background != nil {
+
+		bg := nrgba(r.Background.ToRGBA())
+
+		for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+			for x := img.Rect.Min.X; x < img.Rect.Max.X; x++ {
+				img.SetNRGBA(x, y, bg)
+			}
+		}
+	}
+
+	return img
+}
+
+// paint sets the pixel to c, compositing translucent colors over the checkerboard
+func (r Renderer) paint(img *image.NRGBA, x, y int, c *RGBAColor) {
+
+	if c.A >= 1 {
+		img.SetNRGBA(x, y, nrgba(c))
+		return
+	}
+
+	check := &checkerLight
+	if cs := r.checkerSize(); (x/cs+y/cs)%2 == 1 {
+		check = &checkerDark
+	}
+
+	img.SetNRGBA(x, y, nrgba(composite(c, check).ToRGBA()))
+}
+
+// drawLabel draws the label of c centered horizontally on x, either centered vertically on y or ending at y
+func (r Renderer) drawLabel(img *image.NRGBA, x, y int, middle bool, c *RGBAColor) {
+
+	lines, ink := r.label(c)
+	if len(lines) == 0 {
+		return
+	}
+
+	scale := r.scale()
+	height := (len(lines)*glyphLineHeight - (glyphLineHeight - glyphHeight)) * scale
+
+	y -= height
+	if middle {
+		y += height / 2
+	}
+
+	for _, line := range lines {
+
+		// keep labels of stops at the ends of gradients within the image
+		width := textWidth(line, scale)
+		left := x - width/2
+		left = int(math.Max(float64(img.Rect.Min.X+4*scale), math.Min(float64(img.Rect.Max.X-4*scale-width), float64(left))))
+
+		drawText(img, left, y, line, scale, nrgba(ink.ToRGBA()))
+		y += glyphLineHeight * scale
+	}
+}
+
+func nrgba(c *RGBAColor) color.NRGBA {
+	return color.NRGBA{R: c.R, G: c.G, B: c.B, A: clampUint8(c.A)}
+}
@@ -0,0 +1,224 @@
+package colors
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+)
+
+func testRenderColors() []Color {
+
+	orange, _ := ParseHEX("#ff8800")
+	blue, _ := RGBA(0, 0, 255, 0.5)
+	navy, _ := ParseHEX("#123456")
+
+	return []Color{orange, blue, navy}
+}
+
+// validSVG reports whether s is well formed XML
+func validSVG(t *testing.T, s string) {
+
+	t.Helper()
+
+	dec := xml.NewDecoder(strings.NewReader(s))
+
+	for {
+		if _, err := dec.Token(); err == io.EOF {
+			return
+		} else if err != nil {
+			t.Fatalf("invalid SVG: %v\n%s", err, s)
+		}
+	}
+}
+
+func TestRendererLayouts(t *testing.T) {
+
+	colors := testRenderColors()
+
+	img, err := Renderer{}.Image(colors)
+	Equal(t, err, nil)
+	Equal(t, img.Rect.Dx(), 192)
+	Equal(t, img.Rect.Dy(), 64)
+	Equal(t, img.NRGBAAt(10, 10), color.NRGBA{R: 255, G: 136, A: 255})
+	Equal(t, img.NRGBAAt(160, 60), color.NRGBA{R: 18, G: 52, B: 86, A: 255})
+
+	// the translucent blue is composited over a checkerboard of 8 pixel squares
+	Equal(t, img.NRGBAAt(64, 0), color.NRGBA{R: 128, G: 128, B: 255, A: 255})
+	Equal(t, img.NRGBAAt(72, 0), color.NRGBA{R: 102, G: 102, B: 230, A: 255})
+
+	img, err = Renderer{Layout: LayoutGrid, Size: 10, Gap: 2}.Image(append(colors, colors[0]))
+	Equal(t, err, nil)
+	Equal(t, img.Rect.Dx(), 22)
+	Equal(t, img.Rect.Dy(), 22)
+	Equal(t, img.NRGBAAt(11, 5), color.NRGBA{})
+	Equal(t, img.NRGBAAt(5, 15), color.NRGBA{R: 18, G: 52, B: 86, A: 255})
+
+	img, err = Renderer{Layout: LayoutGrid, Columns: 2, Size: 10}.Image(colors)
+	Equal(t, err, nil)
+	Equal(t, img.Rect.Dx(), 20)
+	Equal(t, img.Rect.Dy(), 20)
+	Equal(t, img.NRGBAAt(15, 15), color.NRGBA{})
+
+	white, _ := ParseHEX("#fff")
+
+	img, err = Renderer{Layout: LayoutWheel, Size: 16, Background: white}.Image(colors)
+	Equal(t, err, nil)
+	Equal(t, img.Rect.Dx(), 64)
+	Equal(t, img.NRGBAAt(0, 0), color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+	Equal(t, img.NRGBAAt(40, 10), color.NRGBA{R: 255, G: 136, A: 255})
+	Equal(t, img.NRGBAAt(10, 20), color.NRGBA{R: 18, G: 52, B: 86, A: 255})
+
+	_, err = Renderer{}.Image(nil)
+	Equal(t, err, ErrNoColors)
+}
+
+func TestRendererLimits(t *testing.T) {
+
+	colors := testRenderColors()
+
+	_, err := Renderer{Size: 1 << 30}.Image(colors)
+	Equal(t, errors.Is(err, ErrImageTooLarge), true)
+
+	_, err = Renderer{Size: 1, Gap: 1 << 30}.Image(colors)
+	Equal(t, errors.Is(err, ErrImageTooLarge), true)
+
+	_, err = Renderer{Size: 10, MaxPixels: 100}.GradientImage(colors)
+	Equal(t, errors.Is(err, ErrImageTooLarge), true)
+	Equal(t, err.Error(), "colors: image too large: 80x10, at most 100 pixels")
+
+	err = Renderer{Size: 10, MaxPixels: 200}.WriteSVG(io.Discard, colors)
+	Equal(t, errors.Is(err, ErrImageTooLarge), true)
+
+	_, err = Renderer{Layout: LayoutWheel, Size: 1}.Image(make([]Color, maxRenderColors+1))
+	Equal(t, errors.Is(err, ErrImageTooLarge), true)
+
+	img, err := Renderer{Layout: LayoutGrid, Columns: -1, Size: 10, Gap: -5, MaxPixels: 400}.Image(append(colors, colors[0]))
+	Equal(t, err, nil)
+	Equal(t, img.Rect.Dx(), 20)
+	Equal(t, img.Rect.Dy(), 20)
+}
+
+func TestRendererLabels(t *testing.T) {
+
+	colors := testRenderColors()
+
+	ink := func(r Renderer, c Color) []string {
+		lines, _ := r.label(c.ToRGBA())
+		return lines
+	}
+
+	r := Renderer{Labels: LabelHex | LabelContrast}
+	Equal(t, ink(r, colors[0]), []string{"#ff8800", "8.77:1"})
+	Equal(t, ink(r, colors[1]), []string{"#0000ff80", "6.45:1"})
+
+	white, _ := ParseHEX("#fff")
+	_, c := r.label(colors[2].ToRGBA())
+	Equal(t, c.String(), "rgb(255,255,255)")
+
+	r = Renderer{Labels: LabelContrast, ContrastWith: white}
+	Equal(t, ink(r, colors[2]), []string{"12.72:1"})
+
+	// labels are drawn in the most readable of black and white
+	img, err := Renderer{Labels: LabelHex}.Image(colors[2:])
+	Equal(t, err, nil)
+
+	found := false
+
+	for x := 0; x < 64 && !found; x++ {
+		for y := 0; y < 64 && !found; y++ {
+			found = img.NRGBAAt(x, y) == color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+		}
+	}
+
+	Equal(t, found, true)
+}
+
+func TestRendererGradient(t *testing.T) {
+
+	black, _ := ParseHEX("#000")
+	white, _ := ParseHEX("#fff")
+
+	img, err := Renderer{Size: 8}.GradientImage([]Color{black, white})
+	Equal(t, err, nil)
+	Equal(t, img.Rect.Dx(), 64)
+	Equal(t, img.Rect.Dy(), 8)
+	Equal(t, img.NRGBAAt(0, 0), color.NRGBA{A: 255})
+
+	// the midpoint is interpolated in OKLab, being perceptually rather than numerically halfway
+	mid := img.NRGBAAt(32, 0)
+	Equal(t, mid.R < 128 && mid.R == mid.G && mid.G == mid.B, true)
+
+	g := newGradient([]Color{black, white}, true)
+	Equal(t, g.position(1), .5)
+	Equal(t, g.at(0).String(), g.at(1).String())
+	Equal(t, g.at(.5).String(), "rgba(255,255,255,1)")
+
+	_, err = Renderer{}.GradientImage(nil)
+	Equal(t, err, ErrNoColors)
+}
+
+func TestRendererPNG(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	Equal(t, Renderer{Layout: LayoutWheel, Labels: LabelHex}.WritePNG(&buf, testRenderColors()), nil)
+
+	img, err := png.Decode(&buf)
+	Equal(t, err, nil)
+	Equal(t, img.Bounds().Dx(), 256)
+
+	buf.Reset()
+	Equal(t, Renderer{}.WriteGradientPNG(&buf, testRenderColors()), nil)
+
+	img, err = png.Decode(&buf)
+	Equal(t, err, nil)
+	Equal(t, img.Bounds().Dx(), 512)
+
+	Equal(t, Renderer{}.WritePNG(&buf, nil), ErrNoColors)
+}
+
+func TestRendererSVG(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	Equal(t, Renderer{Labels: LabelHex, Gap: 4}.WriteSVG(&buf, testRenderColors()), nil)
+
+	s := buf.String()
+	validSVG(t, s)
+
+	Equal(t, strings.HasPrefix(s, `<svg xmlns="http://www.w3.org/2000/svg" width="200" height="64" viewBox="0 0 200 64">`), true)
+	Equal(t, strings.Contains(s, `<pattern id="checker"`), true)
+	Equal(t, strings.Contains(s, `<rect x="68" y="0" width="64" height="64" fill="url(#checker)"/>`+"\n"+
+		`<rect x="68" y="0" width="64" height="64" fill="#0000ff" fill-opacity="0.5"/>`), true)
+	Equal(t, strings.Contains(s, `<text x="168" y="60" fill="#ffffff" font-family="monospace" font-size="10" text-anchor="middle">#123456</text>`), true)
+
+	buf.Reset()
+	Equal(t, Renderer{Layout: LayoutWheel}.WriteSVG(&buf, testRenderColors()[:1]), nil)
+	validSVG(t, buf.String())
+	Equal(t, strings.Contains(buf.String(), `<circle cx="128" cy="128" r="128" fill="#ff8800"/>`), true)
+	Equal(t, strings.Contains(buf.String(), "checker"), false)
+
+	buf.Reset()
+	Equal(t, Renderer{Layout: LayoutWheel}.WriteSVG(&buf, testRenderColors()), nil)
+	validSVG(t, buf.String())
+	Equal(t, strings.Count(buf.String(), "<path"), 4)
+
+	buf.Reset()
+	Equal(t, Renderer{Labels: LabelHex}.WriteGradientSVG(&buf, testRenderColors()), nil)
+	validSVG(t, buf.String())
+	Equal(t, strings.Count(buf.String(), "<stop "), svgGradientSamples)
+	Equal(t, strings.Contains(buf.String(), `text-anchor="start">#ff8800</text>`), true)
+	Equal(t, strings.Contains(buf.String(), `text-anchor="end">#123456</text>`), true)
+
+	buf.Reset()
+	Equal(t, Renderer{Layout: LayoutWheel}.WriteGradientSVG(&buf, testRenderColors()), nil)
+	validSVG(t, buf.String())
+
+	Equal(t, Renderer{}.WriteSVG(&buf, nil), ErrNoColors)
+	Equal(t, Renderer{}.WriteGradientSVG(&buf, nil), ErrNoColors)
+}
@@ -0,0 +1,304 @@
+package colors
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// cssColorFunctions are the CSS color functions whose arguments form a single color literal
+var cssColorFunctions = map[string]bool{
+	"rgb":   true,
+	"rgba":  true,
+	"hsl":   true,
+	"hsla":  true,
+	"hwb":   true,
+	"lab":   true,
+	"lch":   true,
+	"oklab": true,
+	"oklch": true,
+	"color": true,
+}
+
+// CSSColor is a color literal found in CSS, SCSS or LESS source
+type CSSColor struct {
+	// Start and End are the byte offsets of the literal, src[Start:End] being Text
+	Start, End int
+
+	// Text is the literal exactly as written, e.g. #FFF, rgb(0, 0, 0) or red
+	Text string
+
+	// Color is the parsed color, nil when Err is set
+	Color Color
+
+	// Err is the reason a literal that looks like a color could not be parsed,
+	// e.g. a color function syntax not supported by Parse
+	Err error
+}
+
+// cssScanner scans stylesheet source for color literals
+type cssScanner struct {
+	src     []byte
+	pos     int
+	found   []CSSColor
+	pending []CSSColor
+}
+
+// ScanCSS returns every color literal in CSS, SCSS or LESS source in the order they appear: hex colors,
+// color functions such as rgb() and hsl() and named colors. Comments, strings, url() arguments and
+// interpolations are skipped, as is any text ending in a '{' so selectors such as #add or .red and
+// at-rule preludes are not mistaken for colors
+func ScanCSS(src []byte) []CSSColor {
+
+	s := &cssScanner{src: src}
+	s.scan()
+	s.commit()
+
+	return s.found
+}
+
+// commit keeps the colors found since the last statement boundary
+func (s *cssScanner) commit() {
+	s.found = append(s.found, s.pending...)
+	s.pending = s.pending[:0]
+}
+
+func (s *cssScanner) add(start, end int, c Color, err error) {
+
+	if err != nil {
+		c = nil
+	}
+
+	s.pending = append(s.pending, CSSColor{Start: start, End: end, Text: string(s.src[start:end]), Color: c, Err: err})
+}
+
+func (s *cssScanner) peek(offset int) byte {
+
+	if i := s.pos + offset; i < len(s.src) {
+		return s.src[i]
+	}
+
+	return 0
+}
+
+func (s *cssScanner) scan() {
+
+	for s.pos < len(s.src) {
+
+		ch := s.src[s.pos]
+
+		switch {
+		case ch == '/' && s.peek(1) == '*':
+			s.skipBlockComment()
+
+		case ch == '/' && s.peek(1) == '/':
+			s.skipLine()
+
+		case ch == '"' || ch == '\'':
+			s.skipString()
+
+		case ch == '{':
+			s.pending = s.pending[:0]
+			s.pos++
+
+		case ch == '}' || ch == ';':
+			s.commit()
+			s.pos++
+
+		case (ch == '#' || ch == '@') && s.peek(1) == '{':
+			s.pos++
+			s.skipBalanced('{', '}')
+
+		case ch == '#':
+			s.scanHash()
+
+		case ch == '$' || ch == '@' || ch == '.' || ch == '%' || ch == '&':
+			// variables, at-rules, classes and placeholders are never colors
+			s.pos++
+			s.skipIdent()
+
+		case isCSSDigit(ch) || (ch == '-' || ch == '+') && isCSSDigit(s.peek(1)):
+			s.pos++
+			for s.pos < len(s.src) && (isCSSDigit(s.src[s.pos]) || s.src[s.pos] == '.') {
+				s.pos++
+			}
+			s.skipIdent()
+
+		case isCSSIdentStart(ch):
+			s.scanIdent()
+
+		default:
+			s.pos++
+		}
+	}
+}
+
+func (s *cssScanner) skipBlockComment() {
+
+	end := bytes.Index(s.src[s.pos+2:], []byte("*/"))
+	if end == -1 {
+		s.pos = len(s.src)
+		return
+	}
+
+	s.pos += end + 4
+}
+
+func (s *cssScanner) skipLine() {
+
+	end := bytes.IndexByte(s.src[s.pos:], '\n')
+	if end == -1 {
+		s.pos = len(s.src)
+		return
+	}
+
+	s.pos += end + 1
+}
+
+// skipString skips a quoted string, unterminated strings ending at the end of the line
+func (s *cssScanner) skipString() {
+
+	quote := s.src[s.pos]
+	s.pos++
+
+	for s.pos < len(s.src) {
+
+		switch s.src[s.pos] {
+		case '\\':
+			s.pos += 2
+			continue
+		case quote:
+			s.pos++
+			return
+		case '\n':
+			return
+		}
+
+		s.pos++
+	}
+}
+
+// skipBalanced skips from the opening delimiter at pos past its matching close, skipping strings within
+func (s *cssScanner) skipBalanced(opening, closing byte) {
+
+	depth := 0
+
+	for s.pos < len(s.src) {
+
+		switch s.src[s.pos] {
+		case '"', '\'':
+			s.skipString()
+			continue
+		case opening:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				s.pos++
+				return
+			}
+		}
+
+		s.pos++
+	}
+}
+
+func (s *cssScanner) skipIdent() {
+
+	for s.pos < len(s.src) && isCSSIdent(s.src[s.pos]) {
+		s.pos++
+	}
+}
+
+// scanHash scans a hex color or skips an id or other hash token
+func (s *cssScanner) scanHash() {
+
+	start := s.pos
+	s.pos++
+
+	digits := 0
+
+	for s.pos < len(s.src) && isCSSHex(s.src[s.pos]) {
+		s.pos++
+		digits++
+	}
+
+	if s.pos < len(s.src) && isCSSIdent(s.src[s.pos]) {
+		s.skipIdent()
+		return
+	}
+
+	switch digits {
+	case 3, 6:
+		c, err := ParseHEX(string(s.src[start:s.pos]))
+		s.add(start, s.pos, c, err)
+	case 4, 8:
+		s.add(start, s.pos, parseHexAlpha(string(s.src[start+1:s.pos])), nil)
+	}
+}
+
+// parseHexAlpha parses the validated digits of a #rgba or #rrggbbaa hex color
+func parseHexAlpha(digits string) *RGBAColor {
+
+	if len(digits) == 4 {
+		var long strings.Builder
+		for i := 0; i < 4; i++ {
+			long.WriteByte(digits[i])
+			long.WriteByte(digits[i])
+		}
+		digits = long.String()
+	}
+
+	v, _ := strconv.ParseUint(digits, 16, 32)
+
+	return &RGBAColor{
+		R: uint8(v >> 24),
+		G: uint8(v >> 16),
+		B: uint8(v >> 8),
+		A: float64(uint8(v)) / 255,
+	}
+}
+
+// scanIdent scans an identifier, which may be a named color or the name of a function
+func (s *cssScanner) scanIdent() {
+
+	start := s.pos
+	s.skipIdent()
+
+	name := strings.ToLower(string(s.src[start:s.pos]))
+
+	if s.pos < len(s.src) && s.src[s.pos] == '(' {
+
+		switch {
+		case name == "url":
+			s.skipBalanced('(', ')')
+		case cssColorFunctions[name]:
+			s.skipBalanced('(', ')')
+			c, err := Parse(string(s.src[start:s.pos]))
+			s.add(start, s.pos, c, err)
+		}
+
+		// the arguments of any other function, e.g. linear-gradient(), are scanned as normal
+		return
+	}
+
+	if c, err := ParseNamed(name); err == nil {
+		s.add(start, s.pos, c, nil)
+	}
+}
+
+func isCSSDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isCSSHex(ch byte) bool {
+	return isCSSDigit(ch) || ch >= 'a' && ch <= 'f' || ch >= 'A' && ch <= 'F'
+}
+
+func isCSSIdentStart(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch == '_' || ch == '-' || ch >= 0x80
+}
+
+func isCSSIdent(ch byte) bool {
+	return isCSSIdentStart(ch) || isCSSDigit(ch)
+}
@@ -0,0 +1,79 @@
+package colors
+
+import (
+	"testing"
+)
+
+func TestParseNamed(t *testing.T) {
+
+	c, err := ParseNamed("RebeccaPurple")
+	Equal(t, err, nil)
+	Equal(t, c.String(), "#663399")
+
+	c, err = ParseNamed("transparent")
+	Equal(t, err, nil)
+	Equal(t, c.String(), "rgba(0,0,0,0)")
+
+	c, err = ParseNamed("notacolor")
+	Equal(t, err, ErrBadColor)
+	Equal(t, c, nil)
+}
+
+func TestScanCSS(t *testing.T) {
+
+	src := `/* #123 red */
+@import "theme-red.css";
+$brand: #FF8800 !default;
+#add, .red, a:hover #fed {
+  color: Red;
+  background: url(img/#abc.png) no-repeat, linear-gradient(to right, rgba(0, 0, 255, 0.5), #0f08);
+  border: 1px solid rgb(10,20,30); // white
+  content: "blue";
+  margin: -10px 0 #{$gap};
+  --tint: hsl(120 50% 50%);
+}
+@media (min-width: 100px) { a { fill: navy } }
+`
+
+	found := ScanCSS([]byte(src))
+
+	expected := []struct {
+		text  string
+		color string
+	}{
+		{"#FF8800", "#ff8800"},
+		{"Red", "#ff0000"},
+		{"rgba(0, 0, 255, 0.5)", "rgba(0,0,255,0.5)"},
+		{"#0f08", "rgba(0,255,0,0.5333333333333333)"},
+		{"rgb(10,20,30)", "rgb(10,20,30)"},
+		{"hsl(120 50% 50%)", ""},
+		{"navy", "#000080"},
+	}
+
+	Equal(t, len(found), len(expected))
+
+	for i, e := range expected {
+
+		f := found[i]
+
+		Equal(t, f.Text, e.text)
+		Equal(t, src[f.Start:f.End], e.text)
+
+		if e.color == "" {
+			Equal(t, f.Color, nil)
+			Equal(t, f.Err, ErrBadColor)
+			continue
+		}
+
+		Equal(t, f.Err, nil)
+		Equal(t, f.Color.String(), e.color)
+	}
+}
+
+func TestScanCSSUnterminated(t *testing.T) {
+
+	Equal(t, len(ScanCSS([]byte("a { color: #fff"))), 1)
+	Equal(t, len(ScanCSS([]byte("/* red"))), 0)
+	Equal(t, len(ScanCSS([]byte("a { content: 'red"))), 0)
+	Equal(t, len(ScanCSS([]byte("a { color: rgb(1,2,3"))), 1)
+}
@@ -0,0 +1,282 @@
+package colors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Base16 is a terminal's palette of 8 basic and 8 bright colors, used to resolve indexed colors 0-15
+type Base16 [16]RGBColor
+
+// Common terminal palettes
+var (
+	// Base16XTerm is xterm's default palette
+	Base16XTerm = Base16(xtermBase16)
+
+	// Base16VGA is the IBM VGA text mode palette
+	Base16VGA = Base16{
+		{0, 0, 0}, {170, 0, 0}, {0, 170, 0}, {170, 85, 0},
+		{0, 0, 170}, {170, 0, 170}, {0, 170, 170}, {170, 170, 170},
+		{85, 85, 85}, {255, 85, 85}, {85, 255, 85}, {255, 255, 85},
+		{85, 85, 255}, {255, 85, 255}, {85, 255, 255}, {255, 255, 255},
+	}
+
+	// Base16Windows is the Windows console Campbell palette
+	Base16Windows = Base16{
+		{12, 12, 12}, {197, 15, 31}, {19, 161, 14}, {193, 156, 0},
+		{0, 55, 218}, {136, 23, 152}, {58, 150, 221}, {204, 204, 204},
+		{118, 118, 118}, {231, 72, 86}, {22, 198, 12}, {249, 241, 165},
+		{59, 120, 255}, {180, 0, 158}, {97, 214, 214}, {242, 242, 242},
+	}
+
+	// Base16TerminalApp is the macOS Terminal.app palette
+	Base16TerminalApp = Base16{
+		{0, 0, 0}, {194, 54, 33}, {37, 188, 36}, {173, 173, 39},
+		{73, 46, 225}, {211, 56, 211}, {51, 187, 200}, {203, 204, 205},
+		{129, 131, 131}, {252, 57, 31}, {49, 231, 34}, {234, 236, 35},
+		{88, 51, 255}, {249, 53, 248}, {20, 240, 240}, {233, 235, 235},
+	}
+
+	// Base16Solarized is Ethan Schoonover's Solarized palette as mapped onto terminal colors
+	Base16Solarized = Base16{
+		{7, 54, 66}, {220, 50, 47}, {133, 153, 0}, {181, 137, 0},
+		{38, 139, 210}, {211, 54, 130}, {42, 161, 152}, {238, 232, 213},
+		{0, 43, 54}, {203, 75, 22}, {88, 110, 117}, {101, 123, 131},
+		{131, 148, 150}, {108, 113, 196}, {147, 161, 161}, {253, 246, 227},
+	}
+)
+
+// StyledSpan is a run of text sharing the same SGR styling
+type StyledSpan struct {
+	Text string
+
+	// Foreground is the text color, nil meaning the terminal's default
+	Foreground *RGBAColor
+
+	// Background is the background color, nil meaning the terminal's default
+	Background *RGBAColor
+
+	Bold      bool
+	Italic    bool
+	Underline bool
+}
+
+// sameStyle reports whether both spans are styled the same
+func (s StyledSpan) sameStyle(o StyledSpan) bool {
+	return s.Bold == o.Bold && s.Italic == o.Italic && s.Underline == o.Underline &&
+		sameColor(s.Foreground, o.Foreground) && sameColor(s.Background, o.Background)
+}
+
+func sameColor(c, d *RGBAColor) bool {
+
+	if c == nil || d == nil {
+		return c == d
+	}
+
+	return *c == *d
+}
+
+// ParseANSI splits text into spans of consistently styled text according to the SGR escape sequences
+// it contains. Indexed colors 0-15 are resolved using palette and 16-255 using the xterm 256 palette.
+// All other control and OSC sequences are removed from the text
+func ParseANSI(text string, palette Base16) []StyledSpan {
+
+	var spans []StyledSpan
+	var style StyledSpan
+	var sb strings.Builder
+
+	flush := func() {
+
+		if sb.Len() == 0 {
+			return
+		}
+
+		if n := len(spans); n > 0 && spans[n-1].sameStyle(style) {
+			spans[n-1].Text += sb.String()
+		} else {
+			span := style
+			span.Text = sb.String()
+			spans = append(spans, span)
+		}
+
+		sb.Reset()
+	}
+
+	for i := 0; i < len(text); {
+
+		j := strings.IndexByte(text[i:], 0x1b)
+		if j == -1 {
+			sb.WriteString(text[i:])
+			break
+		}
+
+		sb.WriteString(text[i : i+j])
+		i += j + 1
+
+		if i == len(text) {
+			break
+		}
+
+		switch text[i] {
+		case '[':
+			end := i + 1
+			for end < len(text) && text[end] >= 0x20 && text[end] <= 0x3f {
+				end++
+			}
+
+			if end == len(text) {
+				i = end
+				continue
+			}
+
+			if text[end] == 'm' {
+				flush()
+				style.applySGR(text[i+1:end], &palette)
+			}
+
+			i = end + 1
+
+		case ']':
+			// OSC sequences are terminated by BEL or ESC \
+			end := strings.IndexAny(text[i:], "\a\x1b")
+			if end == -1 {
+				i = len(text)
+				continue
+			}
+
+			i += end + 1
+
+			if text[i-1] == 0x1b && i < len(text) && text[i] == '\\' {
+				i++
+			}
+
+		default:
+			i++
+		}
+	}
+
+	flush()
+
+	return spans
+}
+
+// applySGR applies the SGR parameters to the span's style
+func (s *StyledSpan) applySGR(params string, palette *Base16) {
+
+	parts := strings.Split(params, ";")
+
+	for i := 0; i < len(parts); i++ {
+
+		part := parts[i]
+
+		// colon separated sub parameters, e.g. 38:2::255:0:0 or 38:5:208
+		if strings.IndexByte(part, ':') != -1 {
+
+			sub := strings.Split(part, ":")
+			if len(sub) == 6 && sub[1] == "2" {
+				sub = append(sub[:2], sub[3:]...)
+			}
+
+			if c, _ := resolveExtended(sub, palette); c != nil {
+				s.setColor(sub[0], c)
+			}
+
+			continue
+		}
+
+		n, err := strconv.Atoi(part)
+		if err != nil && part != "" {
+			continue
+		}
+
+		switch {
+		case n == 0:
+			*s = StyledSpan{}
+		case n == 1:
+			s.Bold = true
+		case n == 3:
+			s.Italic = true
+		case n == 4:
+			s.Underline = true
+		case n == 22:
+			s.Bold = false
+		case n == 23:
+			s.Italic = false
+		case n == 24:
+			s.Underline = false
+		case n >= 30 && n <= 37:
+			s.Foreground = palette[n-30].ToRGBA()
+		case n == 39:
+			s.Foreground = nil
+		case n >= 40 && n <= 47:
+			s.Background = palette[n-40].ToRGBA()
+		case n == 49:
+			s.Background = nil
+		case n >= 90 && n <= 97:
+			s.Foreground = palette[n-90+8].ToRGBA()
+		case n >= 100 && n <= 107:
+			s.Background = palette[n-100+8].ToRGBA()
+		case n == 38 || n == 48:
+			c, used := resolveExtended(parts[i:], palette)
+			if c != nil {
+				s.setColor(part, c)
+			}
+			i += used
+		}
+	}
+}
+
+// setColor sets the foreground when code is 38 and the background when 48
+func (s *StyledSpan) setColor(code string, c *RGBAColor) {
+	switch code {
+	case "38":
+		s.Foreground = c
+	case "48":
+		s.Background = c
+	}
+}
+
+// resolveExtended resolves an extended color such as 38;5;n or 38;2;r;g;b, returning
+// the color, or nil when invalid, and the number of parameters consumed after the first
+func resolveExtended(parts []string, palette *Base16) (*RGBAColor, int) {
+
+	if len(parts) < 2 {
+		return nil, len(parts) - 1
+	}
+
+	switch parts[1] {
+	case "5":
+		if len(parts) < 3 {
+			return nil, len(parts) - 1
+		}
+
+		n, err := strconv.ParseUint(parts[2], 10, 8)
+		if err != nil {
+			return nil, 2
+		}
+
+		if n < 16 {
+			return palette[n].ToRGBA(), 2
+		}
+
+		return xterm256[n].ToRGBA(), 2
+
+	case "2":
+		if len(parts) < 5 {
+			return nil, len(parts) - 1
+		}
+
+		r, errR := strconv.ParseUint(parts[2], 10, 8)
+		g, errG := strconv.ParseUint(parts[3], 10, 8)
+		b, errB := strconv.ParseUint(parts[4], 10, 8)
+
+		if errR != nil || errG != nil || errB != nil {
+			return nil, 4
+		}
+
+		return &RGBAColor{R: uint8(r), G: uint8(g), B: uint8(b), A: 1}, 4
+
+	default:
+		return nil, 1
+	}
+}
@@ -0,0 +1,55 @@
+package colors
+
+import "testing"
+
+func TestParseANSI(t *testing.T) {
+
+	text := "plain \x1b[1;31mbold red\x1b[22m red\x1b[0m \x1b[38;5;208;48;2;1;2;3morange\x1b[39;49m" +
+		"\x1b]8;;http://x\x07link\x1b]8;;\x1b\\ \x1b[4;94;100mbright\x1b[m\x1b[2K\x1b[38:2::9:8:7mcolon"
+
+	spans := ParseANSI(text, Base16XTerm)
+	Equal(t, len(spans), 8)
+
+	Equal(t, spans[0].Text, "plain ")
+	Equal(t, spans[0].Foreground, nil)
+
+	Equal(t, spans[1].Text, "bold red")
+	Equal(t, spans[1].Bold, true)
+	Equal(t, spans[1].Foreground.String(), "rgba(205,0,0,1)")
+
+	Equal(t, spans[2].Text, " red")
+	Equal(t, spans[2].Bold, false)
+	Equal(t, spans[2].Foreground.String(), "rgba(205,0,0,1)")
+
+	Equal(t, spans[3].Text, " ")
+
+	Equal(t, spans[4].Text, "orange")
+	Equal(t, spans[4].Foreground.String(), "rgba(255,135,0,1)")
+	Equal(t, spans[4].Background.String(), "rgba(1,2,3,1)")
+
+	Equal(t, spans[5].Text, "link ")
+	Equal(t, spans[5].Foreground, nil)
+	Equal(t, spans[5].Background, nil)
+
+	Equal(t, spans[6].Text, "bright")
+	Equal(t, spans[6].Underline, true)
+	Equal(t, spans[6].Foreground.String(), "rgba(92,92,255,1)")
+
+	Equal(t, spans[7].Text, "colon")
+	Equal(t, spans[7].Underline, false)
+	Equal(t, spans[7].Foreground.String(), "rgba(9,8,7,1)")
+
+	spans = ParseANSI("\x1b[4;94;100mbright", Base16VGA)
+	Equal(t, len(spans), 1)
+	Equal(t, spans[0].Underline, true)
+	Equal(t, spans[0].Foreground.String(), "rgba(85,85,255,1)")
+	Equal(t, spans[0].Background.String(), "rgba(85,85,85,1)")
+
+	spans = ParseANSI("\x1b[38:2::9:8:7mcolon\x1b[38;5;1mone", Base16Solarized)
+	Equal(t, len(spans), 2)
+	Equal(t, spans[0].Foreground.String(), "rgba(9,8,7,1)")
+	Equal(t, spans[1].Foreground.String(), "rgba(220,50,47,1)")
+
+	Equal(t, len(ParseANSI("", Base16XTerm)), 0)
+	Equal(t, ParseANSI("\x1b[31", Base16XTerm), nil)
+}
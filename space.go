@@ -0,0 +1,479 @@
+package colors
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrBadColorSpace is returned when parsing an unknown color space name
+	ErrBadColorSpace = errors.New("colors: unknown color space")
+)
+
+// ColorSpace is an RGB color space or CIE XYZ, as used by the CSS color() function
+// https://www.w3.org/TR/css-color-4/#predefined
+type ColorSpace uint8
+
+// Color spaces
+const (
+	// SpaceSRGB is sRGB, the color space of every other Color type
+	SpaceSRGB ColorSpace = iota
+
+	// SpaceSRGBLinear is sRGB without its transfer function, channels being linear light
+	SpaceSRGBLinear
+
+	// SpaceDisplayP3 is Display P3, the wide gamut of Apple displays, using the sRGB transfer function
+	SpaceDisplayP3
+
+	// SpaceA98RGB is Adobe RGB (1998)
+	SpaceA98RGB
+
+	// SpaceProPhotoRGB is ProPhoto RGB, a very wide gamut with a D50 white point
+	SpaceProPhotoRGB
+
+	// SpaceRec2020 is ITU-R BT.2020, the gamut of UHDTV
+	SpaceRec2020
+
+	// SpaceXYZD50 is CIE XYZ relative to a D50 white point
+	SpaceXYZD50
+
+	// SpaceXYZD65 is CIE XYZ relative to a D65 white point
+	SpaceXYZD65
+)
+
+// matrix3 is a 3x3 matrix transforming a color's channels
+type matrix3 [3][3]float64
+
+func (m matrix3) apply(v [3]float64) [3]float64 {
+	return [3]float64{
+		m[0][0]*v[0] + m[0][1]*v[1] + m[0][2]*v[2],
+		m[1][0]*v[0] + m[1][1]*v[1] + m[1][2]*v[2],
+		m[2][0]*v[0] + m[2][1]*v[1] + m[2][2]*v[2],
+	}
+}
+
+var identity = matrix3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+// Bradford chromatic adaptation between the D65 and D50 white points, precomputed by CSS Color 4
+// from its own rounding of the white points so that conversions match browsers exactly
+var (
+	d65ToD50 = matrix3{
+		{1.0479297925449969, 0.022946870601609652, -0.05019226628920524},
+		{0.02962780877005599, 0.9904344267538799, -0.017073799063418826},
+		{-0.009243040646204504, 0.015055191490298152, 0.7518742814281371},
+	}
+	d50ToD65 = matrix3{
+		{0.955473421488075, -0.02309845494876471, 0.06325924320057072},
+		{-0.0283697093338637, 1.0099953980813041, 0.021041441191917323},
+		{0.012314014864481998, -0.020507649298898964, 1.330365926242124},
+	}
+)
+
+// the D65 and D50 white points in XYZ, derived from the same chromaticities
+// as the CSS Color 4 matrices so that the white of every space maps to them
+var (
+	d65White = [3]float64{0.3127 / 0.3290, 1, (1 - 0.3127 - 0.3290) / 0.3290}
+	d50White = [3]float64{0.3457 / 0.3585, 1, (1 - 0.3457 - 0.3585) / 0.3585}
+)
+
+// colorSpace defines a ColorSpace by its transfer function and its linear light primaries in XYZ
+type colorSpace struct {
+	name string
+
+	// toLinear and fromLinear are the transfer function, nil for linear light spaces
+	toLinear, fromLinear func(float64) float64
+
+	// toXYZ and fromXYZ convert linear light channels to and from XYZ relative to the space's white point
+	toXYZ, fromXYZ matrix3
+
+	// d50 reports whether the white point is D50 rather than D65
+	d50 bool
+}
+
+// colorSpaces are the matrices and transfer functions of CSS Color 4
+// https://www.w3.org/TR/css-color-4/#color-conversion-code
+var colorSpaces = map[ColorSpace]colorSpace{
+	SpaceSRGB: {
+		name:       "srgb",
+		toLinear:   signed(srgbToLinear),
+		fromLinear: signed(linearToSRGB),
+		toXYZ:      srgbToXYZ,
+		fromXYZ:    xyzToSRGB,
+	},
+	SpaceSRGBLinear: {
+		name:    "srgb-linear",
+		toXYZ:   srgbToXYZ,
+		fromXYZ: xyzToSRGB,
+	},
+	SpaceDisplayP3: {
+		name:       "display-p3",
+		toLinear:   signed(srgbToLinear),
+		fromLinear: signed(linearToSRGB),
+		toXYZ: matrix3{
+			{0.4865709486482162, 0.26566769316909306, 0.1982172852343625},
+			{0.2289745640697488, 0.6917385218365064, 0.079286914093745},
+			{0, 0.04511338185890264, 1.043944368900976},
+		},
+		fromXYZ: matrix3{
+			{2.493496911941425, -0.9313836179191239, -0.40271078445071684},
+			{-0.8294889695615747, 1.7626640603183463, 0.023624685841943577},
+			{0.03584583024378447, -0.07617238926804182, 0.9568845240076872},
+		},
+	},
+	SpaceA98RGB: {
+		name: "a98-rgb",
+		toLinear: signed(func(v float64) float64 {
+			return math.Pow(v, 563.0/256)
+		}),
+		fromLinear: signed(func(v float64) float64 {
+			return math.Pow(v, 256.0/563)
+		}),
+		toXYZ: matrix3{
+			{0.5766690429101305, 0.1855582379065463, 0.1882286462349947},
+			{0.29734497525053605, 0.6273635662554661, 0.07529145849399788},
+			{0.02703136138641234, 0.07068885253582723, 0.9913375368376388},
+		},
+		fromXYZ: matrix3{
+			{2.0415879038107465, -0.5650069742788596, -0.34473135077832956},
+			{-0.9692436362808795, 1.8759675015077202, 0.04155505740717557},
+			{0.013444280632031142, -0.11836239223101838, 1.0151749943912054},
+		},
+	},
+	SpaceProPhotoRGB: {
+		name: "prophoto-rgb",
+		toLinear: signed(func(v float64) float64 {
+
+			if v <= 16.0/512 {
+				return v / 16
+			}
+
+			return math.Pow(v, 1.8)
+		}),
+		fromLinear: signed(func(v float64) float64 {
+
+			if v < 1.0/512 {
+				return v * 16
+			}
+
+			return math.Pow(v, 1/1.8)
+		}),
+		toXYZ: matrix3{
+			{0.7977666449006423, 0.13518129740053308, 0.0313477341283922},
+			{0.2880748288194013, 0.711835234241873, 0.00008993693872564},
+			{0, 0, 0.8251046025104602},
+		},
+		fromXYZ: matrix3{
+			{1.3457868816471583, -0.25557208737979464, -0.05110186497554526},
+			{-0.5446307051249019, 1.5082477428451468, 0.02052744743642139},
+			{0, 0, 1.2119675456389452},
+		},
+		d50: true,
+	},
+	SpaceRec2020: {
+		name: "rec2020",
+		toLinear: signed(func(v float64) float64 {
+
+			if v < rec2020Beta*4.5 {
+				return v / 4.5
+			}
+
+			return math.Pow((v+rec2020Alpha-1)/rec2020Alpha, 1/0.45)
+		}),
+		fromLinear: signed(func(v float64) float64 {
+
+			if v < rec2020Beta {
+				return v * 4.5
+			}
+
+			return rec2020Alpha*math.Pow(v, 0.45) - (rec2020Alpha - 1)
+		}),
+		toXYZ: matrix3{
+			{0.6369580483012914, 0.14461690358620832, 0.1688809751641721},
+			{0.2627002120112671, 0.6779980715188708, 0.05930171646986196},
+			{0, 0.028072693049087428, 1.060985057710791},
+		},
+		fromXYZ: matrix3{
+			{1.716651187971268, -0.355670783776392, -0.253366281373660},
+			{-0.666684351832489, 1.616481236634939, 0.0157685458139111},
+			{0.017639857445311, -0.042770613257809, 0.942103121235474},
+		},
+	},
+	SpaceXYZD50: {
+		name:    "xyz-d50",
+		toXYZ:   identity,
+		fromXYZ: identity,
+		d50:     true,
+	},
+	SpaceXYZD65: {
+		name:    "xyz-d65",
+		toXYZ:   identity,
+		fromXYZ: identity,
+	},
+}
+
+// the constants of the Rec. 2020 transfer function
+const (
+	rec2020Alpha = 1.09929682680944
+	rec2020Beta  = 0.018053968510807
+)
+
+var (
+	srgbToXYZ = matrix3{
+		{0.41239079926595934, 0.357584339383878, 0.1804807884018343},
+		{0.21263900587151027, 0.715168678767756, 0.07219231536073371},
+		{0.01933081871559182, 0.11919477979462598, 0.9505321522496607},
+	}
+	xyzToSRGB = matrix3{
+		{3.2409699419045226, -1.537383177570094, -0.4986107602930034},
+		{-0.9692436362808796, 1.8759675015077202, 0.04155505740717559},
+		{0.05563007969699366, -0.20397695888897652, 1.0569715142428786},
+	}
+)
+
+// signed extends a transfer function defined on [0, 1] to negative channels by symmetry
+func signed(f func(float64) float64) func(float64) float64 {
+	return func(v float64) float64 {
+
+		if v < 0 {
+			return -f(-v)
+		}
+
+		return f(v)
+	}
+}
+
+// String returns the ColorSpace's CSS name, e.g. display-p3
+func (s ColorSpace) String() string {
+	return colorSpaces[s].name
+}
+
+// ParseColorSpace parses a ColorSpace's CSS name as returned by String, xyz being accepted for
+// xyz-d65, or returns ErrBadColorSpace
+func ParseColorSpace(name string) (ColorSpace, error) {
+
+	name = strings.ToLower(name)
+
+	if name == "xyz" {
+		return SpaceXYZD65, nil
+	}
+
+	for s, cs := range colorSpaces {
+		if cs.name == name {
+			return s, nil
+		}
+	}
+
+	return 0, ErrBadColorSpace
+}
+
+// SpaceColor represents a color in any ColorSpace, its channels being unbounded so that colors
+// outside the space's gamut survive conversions. Converting it to the other Color types maps it
+// into sRGB using ToGamut and GamutCSS
+type SpaceColor struct {
+	Space ColorSpace
+
+	// R, G and B are the red, green and blue channels in [0, 1] within the gamut of RGB spaces,
+	// or X, Y and Z for the XYZ spaces
+	R float64
+	G float64
+	B float64
+
+	// A is the alpha in [0, 1]
+	A float64
+}
+
+// ParseSpaceColor validates and parses a CSS color() function, e.g. color(display-p3 1 0.5 0 / 0.8),
+// into a SpaceColor object. Channels may be numbers, percentages or none, which is treated as 0
+func ParseSpaceColor(s string) (*SpaceColor, error) {
+
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if !strings.HasPrefix(s, "color(") || !strings.HasSuffix(s, ")") {
+		return nil, ErrBadColor
+	}
+
+	body := s[len("color(") : len(s)-1]
+	alpha := "1"
+
+	if i := strings.IndexByte(body, '/'); i != -1 {
+		body, alpha = body[:i], strings.TrimSpace(body[i+1:])
+	}
+
+	fields := strings.Fields(body)
+	if len(fields) != 4 {
+		return nil, ErrBadColor
+	}
+
+	space, err := ParseColorSpace(fields[0])
+	if err != nil {
+		return nil, ErrBadColor
+	}
+
+	var ch [4]float64
+
+	for i, field := range append(fields[1:], alpha) {
+		if ch[i], err = parseSpaceChannel(field); err != nil {
+			return nil, err
+		}
+	}
+
+	if ch[3] < 0 || ch[3] > 1 {
+		return nil, ErrBadColor
+	}
+
+	return &SpaceColor{Space: space, R: ch[0], G: ch[1], B: ch[2], A: ch[3]}, nil
+}
+
+// parseSpaceChannel parses a color() channel, a number, a percentage of 1 or none
+func parseSpaceChannel(s string) (float64, error) {
+
+	if s == "none" {
+		return 0, nil
+	}
+
+	scale := 1.0
+
+	if strings.HasSuffix(s, "%") {
+		s, scale = s[:len(s)-1], .01
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, ErrBadColor
+	}
+
+	return v * scale, nil
+}
+
+// InSpace validates and returns a new SpaceColor object from the provided space, channels and alpha
+func InSpace(space ColorSpace, r, g, b, a float64) (*SpaceColor, error) {
+
+	if _, ok := colorSpaces[space]; !ok || a < 0 || a > 1 {
+		return nil, ErrBadColor
+	}
+
+	return &SpaceColor{Space: space, R: r, G: g, B: b, A: a}, nil
+}
+
+// ToSpace converts any Color to a SpaceColor in the given space
+func ToSpace(c Color, space ColorSpace) *SpaceColor {
+
+	if sc, ok := c.(*SpaceColor); ok {
+		return sc.Convert(space)
+	}
+
+	rgba := c.ToRGBA()
+	srgb := &SpaceColor{R: float64(rgba.R) / 255, G: float64(rgba.G) / 255, B: float64(rgba.B) / 255, A: rgba.A}
+
+	return srgb.Convert(space)
+}
+
+// Convert converts the color to another ColorSpace through XYZ, adapting between the D65 and D50
+// white points with the Bradford transform as CSS Color 4 does. ConvertWith uses another transform
+func (c *SpaceColor) Convert(space ColorSpace) *SpaceColor {
+	return c.convert(space, d65ToD50, d50ToD65)
+}
+
+// convert converts the color to another ColorSpace through XYZ, adapting between the D65 and D50
+// white points with the matrices toD50 and toD65
+func (c *SpaceColor) convert(space ColorSpace, toD50, toD65 matrix3) *SpaceColor {
+
+	if c.Space == space {
+		cp := *c
+		return &cp
+	}
+
+	from, to := colorSpaces[c.Space], colorSpaces[space]
+
+	v := [3]float64{c.R, c.G, c.B}
+
+	if from.toLinear != nil {
+		v = [3]float64{from.toLinear(v[0]), from.toLinear(v[1]), from.toLinear(v[2])}
+	}
+
+	v = from.toXYZ.apply(v)
+
+	switch {
+	case from.d50 && !to.d50:
+		v = toD65.apply(v)
+	case !from.d50 && to.d50:
+		v = toD50.apply(v)
+	}
+
+	v = to.fromXYZ.apply(v)
+
+	if to.fromLinear != nil {
+		v = [3]float64{to.fromLinear(v[0]), to.fromLinear(v[1]), to.fromLinear(v[2])}
+	}
+
+	return &SpaceColor{Space: space, R: v[0], G: v[1], B: v[2], A: c.A}
+}
+
+// String returns the CSS color() representation of the SpaceColor, e.g. color(display-p3 1 0 0),
+// channels having at most 6 decimals and the alpha being left out when opaque
+func (c *SpaceColor) String() string {
+
+	s := "color(" + c.Space.String() + " " + formatFloat(c.R, 6, false) + " " +
+		formatFloat(c.G, 6, false) + " " + formatFloat(c.B, 6, false)
+
+	if c.A != 1 {
+		s += " / " + strconv.FormatFloat(c.A, 'g', -1, 64)
+	}
+
+	return s + ")"
+}
+
+// ToHEX converts the SpaceColor to a HEXColor
+func (c *SpaceColor) ToHEX() *HEXColor {
+	return c.ToRGB().ToHEX()
+}
+
+// ToRGB converts the SpaceColor to an RGBColor, mapping colors outside of sRGB into it
+func (c *SpaceColor) ToRGB() *RGBColor {
+
+	srgb := ToGamut(c, SpaceSRGB, GamutCSS)
+
+	return &RGBColor{R: clampUint8(srgb.R), G: clampUint8(srgb.G), B: clampUint8(srgb.B)}
+}
+
+// ToRGBA converts the SpaceColor to an RGBAColor, mapping colors outside of sRGB into it
+func (c *SpaceColor) ToRGBA() *RGBAColor {
+
+	rgb := c.ToRGB()
+
+	return &RGBAColor{R: rgb.R, G: rgb.G, B: rgb.B, A: c.A}
+}
+
+// IsLight returns whether the color is perceived to be a light color
+func (c *SpaceColor) IsLight() bool {
+	return c.ToRGB().IsLight()
+}
+
+// IsDark returns whether the color is perceived to be a dark color
+func (c *SpaceColor) IsDark() bool {
+	return !c.IsLight()
+}
+
+// RGBA implements color.Color interface.
+// It returns the red, green, blue and alpha values for the color. Each value ranges within [0, 0xffff]
+func (c *SpaceColor) RGBA() (r, g, b, a uint32) {
+	return c.ToRGBA().RGBA()
+}
+
+// Equal reports whether c is the same color as d. Another SpaceColor is compared in c's space,
+// allowing for floating point error, so that colors outside of sRGB are told apart
+func (c *SpaceColor) Equal(d Color) bool {
+
+	sc, ok := d.(*SpaceColor)
+	if !ok {
+		return c.ToRGBA().String() == d.ToRGBA().String()
+	}
+
+	const eps = 1e-9
+
+	sc = sc.Convert(c.Space)
+
+	return math.Abs(c.R-sc.R) < eps && math.Abs(c.G-sc.G) < eps && math.Abs(c.B-sc.B) < eps && c.A == sc.A
+}
@@ -0,0 +1,122 @@
+package colors
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestParseSpaceColor(t *testing.T) {
+
+	tests := []struct {
+		s        string
+		expected *SpaceColor
+	}{
+		{"color(display-p3 1 0 0)", &SpaceColor{Space: SpaceDisplayP3, R: 1, A: 1}},
+		{"COLOR( Rec2020 0.5 50% none / 0.25 )", &SpaceColor{Space: SpaceRec2020, R: .5, G: .5, A: .25}},
+		{"color(xyz 0.95 1 1.09 / 50%)", &SpaceColor{Space: SpaceXYZD65, R: .95, G: 1, B: 1.09, A: .5}},
+		{"color(srgb-linear -0.1 1.2 0)", &SpaceColor{Space: SpaceSRGBLinear, R: -.1, G: 1.2, A: 1}},
+		{"color(prophoto-rgb 0 0 0)", &SpaceColor{Space: SpaceProPhotoRGB, A: 1}},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseSpaceColor(tt.s)
+		Equal(t, err, nil)
+		Equal(t, c, tt.expected)
+	}
+
+	for _, s := range []string{
+		"color(display-p3 1 0)",
+		"color(display-p3 1 0 0 0)",
+		"color(lab 50 0 0)",
+		"color(srgb 1 0 x)",
+		"color(srgb 1 0 0 / 2)",
+		"color(srgb 1 0 0",
+		"rgb(255,0,0)",
+	} {
+		_, err := ParseSpaceColor(s)
+		Equal(t, err, ErrBadColor)
+	}
+
+	c, err := Parse("color(display-p3 1 0 0)")
+	Equal(t, err, nil)
+	Equal(t, c.String(), "color(display-p3 1 0 0)")
+
+	space, err := ParseColorSpace("a98-rgb")
+	Equal(t, err, nil)
+	Equal(t, space, SpaceA98RGB)
+
+	_, err = ParseColorSpace("cmyk")
+	Equal(t, err, ErrBadColorSpace)
+}
+
+func TestSpaceColorConvert(t *testing.T) {
+
+	near := func(c *SpaceColor, r, g, b float64) bool {
+		return math.Abs(c.R-r) < 1e-4 && math.Abs(c.G-g) < 1e-4 && math.Abs(c.B-b) < 1e-4
+	}
+
+	p3Red := &SpaceColor{Space: SpaceDisplayP3, R: 1, A: 1}
+
+	// reference values from the CSS Color 4 conversion code
+	Equal(t, near(p3Red.Convert(SpaceSRGB), 1.0931, -0.2267, -0.1501), true)
+	Equal(t, near(p3Red.Convert(SpaceXYZD65), 0.4866, 0.2290, 0), true)
+	Equal(t, near(p3Red.Convert(SpaceXYZD50), 0.5151, 0.2412, -0.0011), true)
+
+	white, _ := ParseHEX("#fff")
+	Equal(t, near(ToSpace(white, SpaceXYZD65), 0.9505, 1, 1.0891), true)
+	Equal(t, near(ToSpace(white, SpaceXYZD50), 0.9643, 1, 0.8251), true)
+
+	// every space round trips, including colors outside of its gamut
+	orange := &SpaceColor{Space: SpaceSRGB, R: 1, G: .5, B: -.1, A: .5}
+
+	for space := SpaceSRGB; space <= SpaceXYZD65; space++ {
+		converted := orange.Convert(space)
+		Equal(t, converted.Space, space)
+		Equal(t, converted.A, .5)
+		Equal(t, near(converted.Convert(SpaceSRGB), 1, .5, -.1), true)
+		Equal(t, converted.Equal(orange), true)
+		Equal(t, near(ToSpace(white, space).Convert(SpaceSRGB), 1, 1, 1), true)
+	}
+
+	// converting to the other Color types maps into sRGB, keeping the hue
+	Equal(t, p3Red.ToRGB(), &RGBColor{R: 255, G: 11, B: 12})
+	Equal(t, p3Red.ToHEX().String(), "#ff0b0c")
+	Equal(t, orange.ToRGBA(), &RGBAColor{R: 255, G: 128, A: .5})
+
+	// wide gamut colors are told apart even when they clip to the same sRGB color
+	Equal(t, p3Red.Equal(&SpaceColor{Space: SpaceSRGB, R: 1, A: 1}), false)
+	Equal(t, p3Red.Equal(&RGBColor{R: 255, G: 11, B: 12}), true)
+}
+
+func TestSpaceColorEncoding(t *testing.T) {
+
+	c := SpaceColor{Space: SpaceDisplayP3, R: 1, G: .5, B: 1.0 / 3, A: .8}
+	Equal(t, c.String(), "color(display-p3 1 0.5 0.333333 / 0.8)")
+	Equal(t, fmt.Sprintf("%x %s", &c, &c), "#ff7d53 color(display-p3 1 0.5 0.333333 / 0.8)")
+
+	b, err := json.Marshal(c)
+	Equal(t, err, nil)
+	Equal(t, string(b), `"color(display-p3 1 0.5 0.333333 / 0.8)"`)
+
+	var decoded SpaceColor
+	Equal(t, json.Unmarshal(b, &decoded), nil)
+	Equal(t, decoded.Space, SpaceDisplayP3)
+	Equal(t, decoded.B, .333333)
+
+	var a Any
+	Equal(t, json.Unmarshal(b, &a), nil)
+	Equal(t, a.Color.String(), c.String())
+
+	v, err := c.Value()
+	Equal(t, err, nil)
+	Equal(t, v, "color(display-p3 1 0.5 0.333333 / 0.8)")
+
+	Equal(t, decoded.Scan("color(rec2020 0 1 0)"), nil)
+	Equal(t, decoded, SpaceColor{Space: SpaceRec2020, G: 1, A: 1})
+	Equal(t, decoded.Scan(nil), ErrBadColor)
+
+	_, err = InSpace(SpaceDisplayP3, 1, 0, 0, 1.5)
+	Equal(t, err, ErrBadColor)
+}
@@ -0,0 +1,267 @@
+package colors
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+)
+
+const (
+	// svgFontSize is the font size of SVG labels at a scale of 1
+	svgFontSize = 10
+
+	// svgLineHeight is the distance between the baselines of SVG labels at a scale of 1
+	svgLineHeight = 12
+
+	// svgGradientSamples is the number of stops a gradient is sampled into so
+	// that SVG's sRGB interpolation follows the OKLab interpolation of the PNG
+	svgGradientSamples = 64
+
+	// svgWheelWedges is the number of wedges a conic gradient is drawn with
+	svgWheelWedges = 180
+)
+
+// svgWriter writes the elements of a Renderer's SVG image
+type svgWriter struct {
+	*bufio.Writer
+	r Renderer
+}
+
+func (r Renderer) newSVGWriter(w io.Writer, n int, translucent bool) *svgWriter {
+
+	s := &svgWriter{Writer: bufio.NewWriter(w), r: r}
+	b := r.Bounds(n)
+
+	fmt.Fprintf(s, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", b.Dx(), b.Dy(), b.Dx(), b.Dy())
+
+	if translucent {
+		cs := r.checkerSize()
+		fmt.Fprintf(s, `<defs><pattern id="checker" width="%d" height="%d" patternUnits="userSpaceOnUse">`, cs*2, cs*2)
+		fmt.Fprintf(s, `<rect width="%d" height="%d" fill="%s"/>`, cs*2, cs*2, checkerLight.ToHEX())
+		fmt.Fprintf(s, `<rect width="%d" height="%d" fill="%s"/>`, cs, cs, checkerDark.ToHEX())
+		fmt.Fprintf(s, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`, cs, cs, cs, cs, checkerDark.ToHEX())
+		fmt.Fprint(s, "</pattern></defs>\n")
+	}
+
+	if r.Background != nil {
+		fmt.Fprintf(s, `<rect width="%d" height="%d"%s/>`+"\n", b.Dx(), b.Dy(), svgFill(r.Background.ToRGBA()))
+	}
+
+	return s
+}
+
+// shape writes the shape, of an element name and attributes, filled with c and
+// preceded by a checkerboard copy of the shape when c is translucent
+func (s *svgWriter) shape(element, attrs string, c *RGBAColor) {
+
+	if c.A < 1 {
+		fmt.Fprintf(s, `<%s %s fill="url(#checker)"/>`+"\n", element, attrs)
+	}
+
+	fmt.Fprintf(s, "<%s %s%s/>\n", element, attrs, svgFill(c))
+}
+
+// label writes the label of c anchored at x, either centered vertically on y or ending at y
+func (s *svgWriter) label(x, y float64, anchor string, middle bool, c *RGBAColor) {
+
+	lines, ink := s.r.label(c)
+	if len(lines) == 0 {
+		return
+	}
+
+	scale := float64(s.r.scale())
+	lineHeight := svgLineHeight * scale
+
+	// y is the baseline of the first line
+	y -= float64(len(lines)-1) * lineHeight
+	if middle {
+		y += (float64(len(lines))*lineHeight - svgFontSize*scale) / 2
+	}
+
+	for _, line := range lines {
+		fmt.Fprintf(s, `<text x="%s" y="%s" fill="%s" font-family="monospace" font-size="%s" text-anchor="%s">%s</text>`+"\n",
+			svgNumber(x), svgNumber(y), ink.ToHEX(), svgNumber(svgFontSize*scale), anchor, html.EscapeString(line))
+		y += lineHeight
+	}
+}
+
+// wedge writes the wedge of the wheel between the fractions of a turn from and to
+func (s *svgWriter) wedge(from, to float64, c *RGBAColor, stroke bool) {
+
+	radius := float64(s.r.size()*wheelDiameter) / 2
+
+	var attrs string
+
+	if to-from >= 1 {
+		attrs = fmt.Sprintf(`cx="%s" cy="%s" r="%s"`, svgNumber(radius), svgNumber(radius), svgNumber(radius))
+		s.shape("circle", attrs, c)
+		return
+	}
+
+	x1, y1 := s.r.wheelPoint(from, 1)
+	x2, y2 := s.r.wheelPoint(to, 1)
+
+	large := 0
+	if to-from > .5 {
+		large = 1
+	}
+
+	attrs = fmt.Sprintf(`d="M%s %sL%s %sA%s %s 0 %d 1 %s %sZ"`,
+		svgNumber(radius), svgNumber(radius), svgNumber(x1), svgNumber(y1),
+		svgNumber(radius), svgNumber(radius), large, svgNumber(x2), svgNumber(y2))
+
+	// a stroke of the same color hides the hairline seams between adjacent opaque wedges
+	if stroke && c.A >= 1 {
+		attrs += fmt.Sprintf(` stroke="%s" stroke-width="1"`, c.ToHEX())
+	}
+
+	s.shape("path", attrs, c)
+}
+
+func (s *svgWriter) close() error {
+	fmt.Fprint(s, "</svg>\n")
+	return s.Flush()
+}
+
+// WriteSVG renders the colors as swatches and writes them as an SVG image
+func (r Renderer) WriteSVG(w io.Writer, colors []Color) error {
+
+	if err := r.validate(len(colors), len(colors)); err != nil {
+		return err
+	}
+
+	n := len(colors)
+	s := r.newSVGWriter(w, n, anyTranslucent(colors))
+
+	if r.Layout == LayoutWheel {
+
+		for i, c := range colors {
+			s.wedge(float64(i)/float64(n), float64(i+1)/float64(n), c.ToRGBA(), false)
+		}
+
+		for i, c := range colors {
+
+			radius := .6
+			if n == 1 {
+				radius = 0
+			}
+
+			x, y := r.wheelPoint((float64(i)+.5)/float64(n), radius)
+			s.label(x, y, "middle", true, c.ToRGBA())
+		}
+
+		return s.close()
+	}
+
+	for i, c := range colors {
+
+		cell := r.cell(i, n)
+		rgba := c.ToRGBA()
+
+		s.shape("rect", fmt.Sprintf(`x="%d" y="%d" width="%d" height="%d"`, cell.Min.X, cell.Min.Y, cell.Dx(), cell.Dy()), rgba)
+		s.label(float64(cell.Min.X+cell.Max.X)/2, float64(cell.Max.Y-4*r.scale()), "middle", false, rgba)
+	}
+
+	return s.close()
+}
+
+// WriteGradientSVG renders a gradient through the stops, interpolated in OKLab, and writes it as an SVG image
+func (r Renderer) WriteGradientSVG(w io.Writer, stops []Color) error {
+
+	if err := r.validate(len(stops), 0); err != nil {
+		return err
+	}
+
+	g := newGradient(stops, r.Layout == LayoutWheel)
+	s := r.newSVGWriter(w, 0, anyTranslucent(stops))
+	b := r.Bounds(0)
+
+	if r.Layout == LayoutWheel {
+
+		for i := 0; i < svgWheelWedges; i++ {
+			from := float64(i) / svgWheelWedges
+			s.wedge(from, float64(i+1)/svgWheelWedges, g.at(from+.5/svgWheelWedges), true)
+		}
+
+		for i, stop := range stops {
+			x, y := r.wheelPoint(g.position(i), .6)
+			s.label(x, y, "middle", true, stop.ToRGBA())
+		}
+
+		return s.close()
+	}
+
+	fmt.Fprint(s, `<defs><linearGradient id="gradient">`)
+
+	for i := 0; i < svgGradientSamples; i++ {
+
+		t := float64(i) / (svgGradientSamples - 1)
+		c := g.at(t)
+
+		fmt.Fprintf(s, `<stop offset="%s" stop-color="%s"`, svgNumber(t), c.ToHEX())
+
+		if c.A < 1 {
+			fmt.Fprintf(s, ` stop-opacity="%s"`, formatFloat(c.A, 3, false))
+		}
+
+		fmt.Fprint(s, "/>")
+	}
+
+	fmt.Fprint(s, "</linearGradient></defs>\n")
+
+	attrs := fmt.Sprintf(`width="%d" height="%d"`, b.Dx(), b.Dy())
+
+	if anyTranslucent(stops) {
+		fmt.Fprintf(s, `<rect %s fill="url(#checker)"/>`+"\n", attrs)
+	}
+
+	fmt.Fprintf(s, `<rect %s fill="url(#gradient)"/>`+"\n", attrs)
+
+	pad := float64(4 * r.scale())
+
+	for i, stop := range stops {
+
+		x := g.position(i) * float64(b.Dx())
+		anchor := "middle"
+
+		// keep the labels of the end stops within the image
+		switch {
+		case len(stops) > 1 && i == 0:
+			x, anchor = pad, "start"
+		case len(stops) > 1 && i == len(stops)-1:
+			x, anchor = float64(b.Dx())-pad, "end"
+		}
+
+		s.label(x, float64(b.Dy())-pad, anchor, false, stop.ToRGBA())
+	}
+
+	return s.close()
+}
+
+// svgFill returns the fill attributes of c
+func svgFill(c *RGBAColor) string {
+
+	if c.A >= 1 {
+		return fmt.Sprintf(` fill="%s"`, c.ToHEX())
+	}
+
+	return fmt.Sprintf(` fill="%s" fill-opacity="%s"`, c.ToHEX(), formatFloat(c.A, 3, false))
+}
+
+// svgNumber formats a coordinate with at most 2 decimals
+func svgNumber(v float64) string {
+	return formatFloat(math.Round(v*100)/100, 2, false)
+}
+
+func anyTranslucent(colors []Color) bool {
+
+	for _, c := range colors {
+		if c.ToRGBA().A < 1 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,142 @@
+package colors
+
+import (
+	"errors"
+	"math"
+	"unicode/utf16"
+)
+
+var (
+	// ErrBadSwatchFile is returned, wrapped with details, when an Adobe swatch file is malformed
+	ErrBadSwatchFile = errors.New("colors: malformed swatch file")
+)
+
+// SwatchModel is the color model a swatch is defined in
+type SwatchModel uint8
+
+// Swatch models
+const (
+	// SwatchRGB values are red, green and blue in [0, 1]
+	SwatchRGB SwatchModel = iota
+
+	// SwatchCMYK values are cyan, magenta, yellow and black ink coverage in [0, 1]
+	SwatchCMYK
+
+	// SwatchLab values are CIELAB relative to D50, L in [0, 100] and a and b in [-128, 127]
+	SwatchLab
+
+	// SwatchGray values are a single gray level in [0, 1], 0 being black
+	SwatchGray
+)
+
+// SwatchType is how a swatch is used when printing
+type SwatchType uint8
+
+// Swatch types
+const (
+	// SwatchProcess swatches are printed by mixing process inks
+	SwatchProcess SwatchType = iota
+
+	// SwatchSpot swatches are printed using their own premixed ink
+	SwatchSpot
+
+	// SwatchGlobal swatches are process swatches whose edits update everywhere they are used
+	SwatchGlobal
+)
+
+// Swatch is a named color entry of an Adobe swatch file, retaining its original color model
+type Swatch struct {
+	Name string
+
+	// Group is the name of the group the swatch belongs to, empty when ungrouped
+	Group string
+
+	Model SwatchModel
+	Type  SwatchType
+
+	// Values are the channel values of Model, unused channels are 0
+	Values [4]float64
+}
+
+// NewSwatch returns a new process RGB Swatch of c, alpha is discarded
+func NewSwatch(name string, c Color) Swatch {
+
+	rgb := c.ToRGB()
+
+	return Swatch{
+		Name:   name,
+		Model:  SwatchRGB,
+		Values: [4]float64{float64(rgb.R) / 255, float64(rgb.G) / 255, float64(rgb.B) / 255},
+	}
+}
+
+// Color converts the swatch to an RGBColor. CMYK is converted naively without an ink profile
+// and Lab is chromatically adapted from D50 to sRGB's D65 using the Bradford transform
+func (s Swatch) Color() *RGBColor {
+
+	v := s.Values
+
+	switch s.Model {
+	case SwatchCMYK:
+		return &RGBColor{
+			R: clampUint8((1 - v[0]) * (1 - v[3])),
+			G: clampUint8((1 - v[1]) * (1 - v[3])),
+			B: clampUint8((1 - v[2]) * (1 - v[3])),
+		}
+	case SwatchLab:
+		return labD50ToRGB(v[0], v[1], v[2])
+	case SwatchGray:
+		g := clampUint8(v[0])
+		return &RGBColor{R: g, G: g, B: g}
+	default:
+		return &RGBColor{R: clampUint8(v[0]), G: clampUint8(v[1]), B: clampUint8(v[2])}
+	}
+}
+
+// NamedColor returns the swatch's name and Color
+func (s Swatch) NamedColor() NamedColor {
+	return NamedColor{Name: s.Name, Color: s.Color()}
+}
+
+// labD50ToRGB converts CIELAB relative to D50 to sRGB, clipping out of gamut channels
+func labD50ToRGB(l, a, b float64) *RGBColor {
+
+	fy := (l + 16) / 116
+	fx := fy + a/500
+	fz := fy - b/200
+
+	finv := func(t float64) float64 {
+		if t3 := t * t * t; t3 > 216.0/24389 {
+			return t3
+		}
+		return (116*t - 16) / (24389.0 / 27)
+	}
+
+	// Lab swatches are relative to D50, adapted to sRGB's D65 white
+	xyz := d50ToD65.apply([3]float64{finv(fx) * d50White[0], finv(fy) * d50White[1], finv(fz) * d50White[2]})
+	lin := xyzToSRGB.apply(xyz)
+
+	return &RGBColor{
+		R: clampUint8(linearToSRGB(math.Max(lin[0], 0))),
+		G: clampUint8(linearToSRGB(math.Max(lin[1], 0))),
+		B: clampUint8(linearToSRGB(math.Max(lin[2], 0))),
+	}
+}
+
+// encodeUTF16 returns the null terminated UTF-16 code units of s
+func encodeUTF16(s string) []uint16 {
+	return append(utf16.Encode([]rune(s)), 0)
+}
+
+// decodeUTF16 decodes UTF-16 code units, stopping at the first null
+func decodeUTF16(units []uint16) string {
+
+	for i, u := range units {
+		if u == 0 {
+			units = units[:i]
+			break
+		}
+	}
+
+	return string(utf16.Decode(units))
+}
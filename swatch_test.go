@@ -0,0 +1,96 @@
+package colors
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func testSwatches() []Swatch {
+
+	red, _ := ParseHEX("#ff0000")
+
+	return []Swatch{
+		NewSwatch("Red", red),
+		{Name: "Ink", Group: "Print", Model: SwatchCMYK, Type: SwatchSpot, Values: [4]float64{1, 0.5, 0, 0.25}},
+		{Name: "Lab White", Group: "Print", Model: SwatchLab, Type: SwatchGlobal, Values: [4]float64{100, 0, 0}},
+		{Name: "Grày", Model: SwatchGray, Values: [4]float64{0.5}},
+	}
+}
+
+func TestSwatchColor(t *testing.T) {
+
+	s := testSwatches()
+	Equal(t, s[0].Color().String(), "rgb(255,0,0)")
+	Equal(t, s[1].Color().String(), "rgb(0,96,191)")
+	Equal(t, s[2].Color().String(), "rgb(255,255,255)")
+	Equal(t, s[3].Color().String(), "rgb(128,128,128)")
+	Equal(t, s[3].NamedColor().Name, "Grày")
+
+	lab := Swatch{Model: SwatchLab, Values: [4]float64{54.29, 80.80, 69.89}}
+	Equal(t, lab.Color().String(), "rgb(255,0,0)")
+}
+
+func TestASE(t *testing.T) {
+
+	swatches := testSwatches()
+
+	var buf bytes.Buffer
+	Equal(t, WriteASE(&buf, swatches), nil)
+	Equal(t, string(buf.Bytes()[:4]), "ASEF")
+
+	// 4 colors plus the Print group's start and end
+	Equal(t, buf.Bytes()[11], byte(6))
+
+	read, err := ReadASE(bytes.NewReader(buf.Bytes()))
+	Equal(t, err, nil)
+	Equal(t, len(read), 4)
+
+	for i, s := range read {
+		Equal(t, s.Name, swatches[i].Name)
+		Equal(t, s.Group, swatches[i].Group)
+		Equal(t, s.Model, swatches[i].Model)
+		Equal(t, s.Type, swatches[i].Type)
+		Equal(t, s.Color().String(), swatches[i].Color().String())
+	}
+
+	_, err = ReadASE(bytes.NewReader([]byte("ASEX\x00\x01\x00\x00\x00\x00\x00\x00")))
+	Equal(t, errors.Is(err, ErrBadSwatchFile), true)
+
+	_, err = ReadASE(bytes.NewReader(buf.Bytes()[:30]))
+	Equal(t, errors.Is(err, ErrBadSwatchFile), true)
+}
+
+func TestACO(t *testing.T) {
+
+	swatches := testSwatches()
+
+	var buf bytes.Buffer
+	Equal(t, WriteACO(&buf, swatches), nil)
+
+	read, err := ReadACO(bytes.NewReader(buf.Bytes()))
+	Equal(t, err, nil)
+	Equal(t, len(read), 4)
+
+	for i, s := range read {
+		Equal(t, s.Name, swatches[i].Name)
+		Equal(t, s.Group, "")
+		Equal(t, s.Model, swatches[i].Model)
+		Equal(t, s.Type, SwatchProcess)
+		Equal(t, s.Color().String(), swatches[i].Color().String())
+	}
+
+	// version 1 only, HSB pure green
+	v1 := []byte{0, 1, 0, 1, 0, 1, 0x55, 0x55, 0xff, 0xff, 0xff, 0xff, 0, 0}
+	read, err = ReadACO(bytes.NewReader(v1))
+	Equal(t, err, nil)
+	Equal(t, len(read), 1)
+	Equal(t, read[0].Name, "")
+	Equal(t, read[0].Color().String(), "rgb(0,255,0)")
+
+	_, err = ReadACO(bytes.NewReader([]byte{0, 3, 0, 0}))
+	Equal(t, errors.Is(err, ErrBadSwatchFile), true)
+
+	_, err = ReadACO(bytes.NewReader([]byte{0, 1, 0, 1, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0}))
+	Equal(t, err.Error(), "colors: malformed swatch file: ACO color 0: unsupported color space 3")
+}
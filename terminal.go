@@ -0,0 +1,270 @@
+package colors
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// maxPendingEscape is the longest incomplete escape sequence held back between writes,
+// anything longer is assumed not to be an escape sequence and written as is
+const maxPendingEscape = 64
+
+// DetectANSIMode returns the color support of the terminal behind w.
+//
+// FORCE_COLOR (0-3) and CLICOLOR_FORCE override detection, NO_COLOR and CLICOLOR=0 disable
+// colors, and otherwise colors are only enabled when w is a terminal or known CI log.
+// The level is then determined from COLORTERM, WT_SESSION and TERM
+func DetectANSIMode(w io.Writer) ANSIMode {
+	return detectANSIMode(os.Getenv, isTerminal(w), runtime.GOOS)
+}
+
+func detectANSIMode(getenv func(string) string, tty bool, goos string) ANSIMode {
+
+	var forced ANSIMode
+
+	switch v := getenv("FORCE_COLOR"); v {
+	case "":
+	case "0", "false":
+		return ANSINone
+	case "2":
+		forced = ANSI256
+	case "3":
+		forced = ANSITrueColor
+	default:
+		forced = ANSI16
+	}
+
+	if forced == ANSINone {
+
+		if getenv("NO_COLOR") != "" {
+			return ANSINone
+		}
+
+		if v := getenv("CLICOLOR_FORCE"); v != "" && v != "0" {
+			forced = ANSI16
+		}
+	}
+
+	ci := detectCIMode(getenv)
+
+	if forced == ANSINone && (getenv("CLICOLOR") == "0" || (!tty && ci == ANSINone)) {
+		return ANSINone
+	}
+
+	var mode ANSIMode
+	term := getenv("TERM")
+	colorterm := strings.ToLower(getenv("COLORTERM"))
+
+	switch {
+	case term == "dumb":
+	case colorterm == "truecolor" || colorterm == "24bit":
+		mode = ANSITrueColor
+	case getenv("WT_SESSION") != "":
+		mode = ANSITrueColor
+	case strings.Contains(term, "256color"):
+		mode = ANSI256
+	case term != "" || goos == "windows":
+		mode = ANSI16
+	}
+
+	if !tty && ci > mode {
+		mode = ci
+	}
+
+	if forced > mode {
+		mode = forced
+	}
+
+	return mode
+}
+
+// detectCIMode returns the color support of known CI log viewers
+func detectCIMode(getenv func(string) string) ANSIMode {
+
+	if getenv("GITHUB_ACTIONS") == "true" || getenv("GITEA_ACTIONS") == "true" {
+		return ANSITrueColor
+	}
+
+	if getenv("CI") == "" {
+		return ANSINone
+	}
+
+	for _, name := range []string{"GITLAB_CI", "BUILDKITE", "CIRCLECI", "TRAVIS", "APPVEYOR", "DRONE"} {
+		if getenv(name) != "" {
+			return ANSI16
+		}
+	}
+
+	return ANSINone
+}
+
+// ANSIWriter is an io.Writer that rewrites the color escape sequences written through it
+// to the nearest colors supported by its mode, or strips them when the mode is ANSINone
+type ANSIWriter struct {
+	w       io.Writer
+	mode    ANSIMode
+	pending []byte
+}
+
+// NewANSIWriter returns a new ANSIWriter writing to w and degrading colors to mode
+func NewANSIWriter(w io.Writer, mode ANSIMode) *ANSIWriter {
+	return &ANSIWriter{w: w, mode: mode}
+}
+
+// NewAutoANSIWriter returns a new ANSIWriter writing to w and degrading colors to the mode
+// returned by DetectANSIMode
+func NewAutoANSIWriter(w io.Writer) *ANSIWriter {
+	return NewANSIWriter(w, DetectANSIMode(w))
+}
+
+// Mode returns the mode colors are degraded to
+func (a *ANSIWriter) Mode() ANSIMode {
+	return a.mode
+}
+
+// Write implements io.Writer. Escape sequences split across writes are held back until
+// complete so they can be rewritten as a whole, Flush writing out an incomplete one
+func (a *ANSIWriter) Write(p []byte) (int, error) {
+
+	if a.mode == ANSITrueColor && len(a.pending) == 0 {
+		return a.w.Write(p)
+	}
+
+	buf := append(a.pending, p...)
+	a.pending = nil
+	out := make([]byte, 0, len(buf))
+
+	for i := 0; i < len(buf); {
+
+		if buf[i] != 0x1b {
+
+			j := bytes.IndexByte(buf[i:], 0x1b)
+			if j == -1 {
+				out = append(out, buf[i:]...)
+				break
+			}
+
+			out = append(out, buf[i:i+j]...)
+			i += j
+			continue
+		}
+
+		if i+1 == len(buf) {
+			a.pending = append([]byte(nil), buf[i:]...)
+			break
+		}
+
+		if buf[i+1] != '[' {
+			out = append(out, buf[i])
+			i++
+			continue
+		}
+
+		// skip the parameter and intermediate bytes of the control sequence
+		j := i + 2
+		for j < len(buf) && buf[j] >= 0x20 && buf[j] <= 0x3f {
+			j++
+		}
+
+		if j == len(buf) {
+			if j-i <= maxPendingEscape {
+				a.pending = append([]byte(nil), buf[i:]...)
+			} else {
+				out = append(out, buf[i:]...)
+			}
+			break
+		}
+
+		if buf[j] == 'm' {
+			out = append(out, a.rewriteSGR(string(buf[i+2:j]))...)
+		} else {
+			out = append(out, buf[i:j+1]...)
+		}
+
+		i = j + 1
+	}
+
+	if len(out) > 0 {
+		if _, err := a.w.Write(out); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush writes out the incomplete escape sequence held back at the end of the stream, unchanged
+// as it can't be rewritten. It should be called once nothing more will be written
+func (a *ANSIWriter) Flush() error {
+
+	if len(a.pending) == 0 {
+		return nil
+	}
+
+	_, err := a.w.Write(a.pending)
+	a.pending = nil
+
+	return err
+}
+
+// rewriteSGR returns the SGR sequence with the given parameters with its colors degraded to the writer's mode
+func (a *ANSIWriter) rewriteSGR(params string) string {
+
+	if a.mode == ANSINone {
+		return ""
+	}
+
+	parts := strings.Split(params, ";")
+	out := make([]string, 0, len(parts))
+
+	for i := 0; i < len(parts); i++ {
+
+		if (parts[i] != "38" && parts[i] != "48") || i+1 == len(parts) {
+			out = append(out, parts[i])
+			continue
+		}
+
+		base := 30
+		if parts[i] == "48" {
+			base = 40
+		}
+
+		switch {
+		case parts[i+1] == "5" && i+2 < len(parts):
+
+			n, err := strconv.ParseUint(parts[i+2], 10, 8)
+			if err != nil || a.mode != ANSI16 {
+				out = append(out, parts[i:i+3]...)
+			} else if n < 16 {
+				out = append(out, ansiBasicParam(int(n), base))
+			} else {
+				out = append(out, ansiParams(&xterm256[n], ANSI16, base))
+			}
+
+			i += 2
+
+		case parts[i+1] == "2" && i+4 < len(parts):
+
+			r, errR := strconv.ParseUint(parts[i+2], 10, 8)
+			g, errG := strconv.ParseUint(parts[i+3], 10, 8)
+			b, errB := strconv.ParseUint(parts[i+4], 10, 8)
+
+			if errR != nil || errG != nil || errB != nil {
+				out = append(out, parts[i:i+5]...)
+			} else {
+				out = append(out, ansiParams(&RGBColor{R: uint8(r), G: uint8(g), B: uint8(b)}, a.mode, base))
+			}
+
+			i += 4
+
+		default:
+			out = append(out, parts[i])
+		}
+	}
+
+	return "\x1b[" + strings.Join(out, ";") + "m"
+}
@@ -0,0 +1,5 @@
+package colors
+
+import "syscall"
+
+const ioctlReadTermios = syscall.TCGETS
@@ -0,0 +1,10 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+
+package colors
+
+import "io"
+
+// isTerminal reports whether w is a terminal, which can't be detected on this platform
+func isTerminal(w io.Writer) bool {
+	return false
+}
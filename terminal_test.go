@@ -0,0 +1,103 @@
+package colors
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestDetectANSIMode(t *testing.T) {
+
+	env := func(vars ...string) func(string) string {
+		m := make(map[string]string)
+		for i := 0; i < len(vars); i += 2 {
+			m[vars[i]] = vars[i+1]
+		}
+		return func(k string) string { return m[k] }
+	}
+
+	Equal(t, detectANSIMode(env(), false, "linux"), ANSINone)
+	Equal(t, detectANSIMode(env(), true, "linux"), ANSINone)
+	Equal(t, detectANSIMode(env(), true, "windows"), ANSI16)
+	Equal(t, detectANSIMode(env("TERM", "xterm"), true, "linux"), ANSI16)
+	Equal(t, detectANSIMode(env("TERM", "dumb"), true, "linux"), ANSINone)
+	Equal(t, detectANSIMode(env("TERM", "tmux-256color"), true, "linux"), ANSI256)
+	Equal(t, detectANSIMode(env("TERM", "xterm-256color", "COLORTERM", "truecolor"), true, "linux"), ANSITrueColor)
+	Equal(t, detectANSIMode(env("TERM", "xterm-256color", "WT_SESSION", "abc"), true, "linux"), ANSITrueColor)
+	Equal(t, detectANSIMode(env("TERM", "xterm-256color"), false, "linux"), ANSINone)
+
+	Equal(t, detectANSIMode(env("TERM", "xterm-256color", "NO_COLOR", "1"), true, "linux"), ANSINone)
+	Equal(t, detectANSIMode(env("TERM", "xterm-256color", "CLICOLOR", "0"), true, "linux"), ANSINone)
+	Equal(t, detectANSIMode(env("TERM", "xterm-256color", "FORCE_COLOR", "0"), true, "linux"), ANSINone)
+	Equal(t, detectANSIMode(env("NO_COLOR", "1", "FORCE_COLOR", "1"), false, "linux"), ANSI16)
+	Equal(t, detectANSIMode(env("FORCE_COLOR", "3"), false, "linux"), ANSITrueColor)
+	Equal(t, detectANSIMode(env("TERM", "xterm-256color", "FORCE_COLOR", "1"), false, "linux"), ANSI256)
+	Equal(t, detectANSIMode(env("CLICOLOR_FORCE", "1"), false, "linux"), ANSI16)
+
+	Equal(t, detectANSIMode(env("CI", "true", "GITHUB_ACTIONS", "true"), false, "linux"), ANSITrueColor)
+	Equal(t, detectANSIMode(env("CI", "true", "GITLAB_CI", "true"), false, "linux"), ANSI16)
+	Equal(t, detectANSIMode(env("CI", "true"), false, "linux"), ANSINone)
+
+	Equal(t, DetectANSIMode(&bytes.Buffer{}) <= ANSITrueColor, true)
+}
+
+func TestANSIWriter(t *testing.T) {
+
+	in := "a\x1b[1;38;2;255;135;0mb\x1b[48;5;208;4mc\x1b[0m\x1b[2Kd\x1bx"
+
+	expected := map[ANSIMode]string{
+		ANSITrueColor: in,
+		ANSI256:       "a\x1b[1;38;5;208mb\x1b[48;5;208;4mc\x1b[0m\x1b[2Kd\x1bx",
+		ANSI16:        "a\x1b[1;91mb\x1b[101;4mc\x1b[0m\x1b[2Kd\x1bx",
+		ANSINone:      "abc\x1b[2Kd\x1bx",
+	}
+
+	for mode, out := range expected {
+
+		var buf bytes.Buffer
+		w := NewANSIWriter(&buf, mode)
+		Equal(t, w.Mode(), mode)
+
+		n, err := w.Write([]byte(in))
+		Equal(t, err, nil)
+		Equal(t, n, len(in))
+		Equal(t, buf.String(), out)
+
+		// split every escape sequence across writes
+		buf.Reset()
+
+		for i := 0; i < len(in); i++ {
+			_, err = w.Write([]byte{in[i]})
+			Equal(t, err, nil)
+		}
+
+		Equal(t, buf.String(), out)
+	}
+
+	var buf bytes.Buffer
+	w := NewANSIWriter(&buf, ANSI16)
+	_, _ = w.Write([]byte(ColorizeMode("x", &RGBColor{R: 0, G: 0, B: 238}, nil, ANSI256) + "\x1b[38;5;4m"))
+	Equal(t, buf.String(), "\x1b[34mx\x1b[0m\x1b[34m")
+
+	Equal(t, NewAutoANSIWriter(&buf).Mode() <= ANSITrueColor, true)
+
+	// an incomplete escape sequence is held back until flushed
+	buf.Reset()
+	_, _ = w.Write([]byte("x\x1b[38;5"))
+	Equal(t, buf.String(), "x")
+	Equal(t, w.Flush(), nil)
+	Equal(t, buf.String(), "x\x1b[38;5")
+	Equal(t, w.Flush(), nil)
+	Equal(t, buf.String(), "x\x1b[38;5")
+}
+
+func TestIsTerminal(t *testing.T) {
+
+	f, err := os.Open(os.DevNull)
+	Equal(t, err, nil)
+	defer f.Close()
+
+	// /dev/null is a character device but not a terminal
+	Equal(t, isTerminal(f), false)
+	Equal(t, isTerminal(&bytes.Buffer{}), false)
+}
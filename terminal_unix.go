@@ -0,0 +1,25 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package colors
+
+import (
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// isTerminal reports whether w is a terminal, by reading its terminal attributes
+func isTerminal(w io.Writer) bool {
+
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	var termios syscall.Termios
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), ioctlReadTermios, uintptr(unsafe.Pointer(&termios)))
+
+	return errno == 0
+}
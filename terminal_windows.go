@@ -0,0 +1,20 @@
+package colors
+
+import (
+	"io"
+	"os"
+	"syscall"
+)
+
+// isTerminal reports whether w is a console
+func isTerminal(w io.Writer) bool {
+
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	var mode uint32
+
+	return syscall.GetConsoleMode(syscall.Handle(f.Fd()), &mode) == nil
+}
@@ -0,0 +1,576 @@
+// Package tokens reads and writes W3C Design Tokens Community Group (DTCG) token files,
+// resolving color tokens to colors.Color values.
+//
+// Token files are read into a Tree of Nodes preserving the order of the file, so that
+// writing the Tree back out produces the same structure.
+package tokens
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-playground/colors"
+)
+
+// TypeColor is the $type of color tokens
+const TypeColor = "color"
+
+var (
+	// ErrAliasCycle is returned, wrapped with the cycle's path, when aliases reference each other in a loop
+	ErrAliasCycle = errors.New("tokens: alias cycle")
+
+	// ErrUnknownAlias is returned, wrapped with the alias, when an alias references a token that does not exist
+	ErrUnknownAlias = errors.New("tokens: unknown alias")
+)
+
+// Node is a group or token within a Tree, a Node being a token when it has a value
+type Node struct {
+	// Name is the Node's key within its parent group
+	Name string
+
+	// Path is the dot separated path of the Node from the root, e.g. brand.primary
+	Path string
+
+	// Type is the $type declared on the Node, empty when it is inherited
+	Type string
+
+	// Description is the Node's $description
+	Description string
+
+	// Extensions is the raw JSON of the Node's $extensions
+	Extensions json.RawMessage
+
+	// Children are the groups and tokens of a group in file order
+	Children []*Node
+
+	// Value is the raw JSON of a token's $value, nil for groups
+	Value json.RawMessage
+
+	// Alias is the path a token's $value references, e.g. brand.primary for {brand.primary}
+	Alias string
+
+	// Color is the resolved color of color tokens, including aliases of color tokens
+	Color colors.Color
+
+	// resolvedType is the declared or inherited $type
+	resolvedType string
+
+	// structured records that the color was read from a {colorSpace, components} object
+	structured bool
+
+	// hex is the hex fallback of the structured color, empty when it had none
+	hex string
+}
+
+// IsToken reports whether the Node is a token rather than a group
+func (n *Node) IsToken() bool {
+	return n.Value != nil || n.Color != nil || n.Alias != ""
+}
+
+// ResolvedType returns the Node's declared $type or the $type inherited from its groups or alias
+func (n *Node) ResolvedType() string {
+	return n.resolvedType
+}
+
+// Child returns the direct child of the given name, or nil
+func (n *Node) Child(name string) *Node {
+
+	for _, c := range n.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// Tree is a parsed design token file
+type Tree struct {
+	Root  *Node
+	index map[string]*Node
+}
+
+// New returns a new empty Tree
+func New() *Tree {
+	return &Tree{Root: &Node{}, index: make(map[string]*Node)}
+}
+
+// Lookup returns the Node at the dot separated path, or nil
+func (t *Tree) Lookup(path string) *Node {
+	return t.index[path]
+}
+
+// Colors returns every color token in file order, named by path
+func (t *Tree) Colors() []colors.NamedColor {
+
+	var named []colors.NamedColor
+
+	walk(t.Root, func(n *Node) {
+		if n.Color != nil {
+			named = append(named, colors.NamedColor{Name: n.Path, Color: n.Color})
+		}
+	})
+
+	return named
+}
+
+// SetColor sets the color token at the dot separated path, creating any missing groups.
+// An existing token at the path is replaced with a color token
+func (t *Tree) SetColor(path string, c colors.Color) {
+
+	n := t.Root
+
+	for i, name := range strings.Split(path, ".") {
+
+		child := n.Child(name)
+
+		if child == nil {
+			child = &Node{Name: name, Path: joinPath(n.Path, name), resolvedType: n.resolvedType}
+			n.Children = append(n.Children, child)
+			t.index[child.Path] = child
+		}
+
+		if i == strings.Count(path, ".") {
+			if child.resolvedType != TypeColor {
+				child.Type = TypeColor
+				child.resolvedType = TypeColor
+			}
+			child.Value = nil
+			child.Alias = ""
+			child.Color = c
+
+			if child.hex != "" {
+				child.hex = c.ToHEX().String()
+			}
+		}
+
+		n = child
+	}
+}
+
+func walk(n *Node, fn func(*Node)) {
+
+	fn(n)
+
+	for _, c := range n.Children {
+		walk(c, fn)
+	}
+}
+
+func joinPath(parent, name string) string {
+
+	if parent == "" {
+		return name
+	}
+
+	return parent + "." + name
+}
+
+// parseAlias returns the path of an alias value such as {brand.primary}
+func parseAlias(raw json.RawMessage) (string, bool) {
+
+	var s string
+
+	if json.Unmarshal(raw, &s) != nil || len(s) < 3 || s[0] != '{' || s[len(s)-1] != '}' {
+		return "", false
+	}
+
+	return s[1 : len(s)-1], true
+}
+
+// member is a key and raw value of a JSON object
+type member struct {
+	key   string
+	value json.RawMessage
+}
+
+// decodeObject decodes a JSON object into its members, preserving their order
+func decodeObject(raw json.RawMessage) ([]member, error) {
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, fmt.Errorf("expected an object")
+	}
+
+	var members []member
+
+	for dec.More() {
+
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var m member
+		m.key = tok.(string)
+
+		if err = dec.Decode(&m.value); err != nil {
+			return nil, err
+		}
+
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+// Parse reads a DTCG token file, resolving aliases and color values. String color
+// values are parsed using colors.Parse and structured values must be in a color space
+// of the CSS color() function, such as srgb, or provide a hex fallback. Structured colors
+// are read as *colors.SpaceColor, keeping their components exact, unless only the hex
+// fallback is understood
+func Parse(r io.Reader) (*Tree, error) {
+
+	var raw json.RawMessage
+
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	t := New()
+
+	if err := t.parseNode(t.Root, raw); err != nil {
+		return nil, err
+	}
+
+	if err := t.resolve(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *Tree) parseNode(n *Node, raw json.RawMessage) error {
+
+	members, err := decodeObject(raw)
+	if err != nil {
+		return fmt.Errorf("tokens: %s: %v", displayPath(n.Path), err)
+	}
+
+	for _, m := range members {
+
+		switch m.key {
+		case "$type":
+			if err := json.Unmarshal(m.value, &n.Type); err != nil {
+				return fmt.Errorf("tokens: %s: invalid $type: %v", displayPath(n.Path), err)
+			}
+			n.resolvedType = n.Type
+		case "$description":
+			if err := json.Unmarshal(m.value, &n.Description); err != nil {
+				return fmt.Errorf("tokens: %s: invalid $description: %v", displayPath(n.Path), err)
+			}
+		case "$extensions":
+			n.Extensions = m.value
+		case "$value":
+			n.Value = m.value
+		}
+	}
+
+	if n.Value != nil {
+		if alias, ok := parseAlias(n.Value); ok {
+			n.Alias = alias
+		}
+		return nil
+	}
+
+	for _, m := range members {
+
+		if strings.HasPrefix(m.key, "$") {
+			continue
+		}
+
+		child := &Node{Name: m.key, Path: joinPath(n.Path, m.key), resolvedType: n.resolvedType}
+		n.Children = append(n.Children, child)
+		t.index[child.Path] = child
+
+		if err := t.parseNode(child, m.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolve resolves every alias and color value in the Tree
+func (t *Tree) resolve() error {
+
+	const (
+		_ = iota
+		visiting
+		done
+	)
+
+	state := make(map[*Node]int)
+	var stack []string
+	var visit func(n *Node) error
+
+	visit = func(n *Node) error {
+
+		switch state[n] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %s -> %s", ErrAliasCycle, strings.Join(stack, " -> "), n.Path)
+		}
+
+		state[n] = visiting
+		stack = append(stack, n.Path)
+
+		if n.Alias != "" {
+
+			target := t.index[n.Alias]
+
+			if target == nil || !target.IsToken() {
+				return fmt.Errorf("%w: %s references {%s}", ErrUnknownAlias, n.Path, n.Alias)
+			}
+
+			if err := visit(target); err != nil {
+				return err
+			}
+
+			if n.Type == "" {
+				n.resolvedType = target.resolvedType
+			}
+
+			if n.resolvedType == TypeColor {
+				n.Color = target.Color
+			}
+
+		} else if n.resolvedType == TypeColor {
+
+			c, sc, err := parseColor(n.Value)
+			if err != nil {
+				return fmt.Errorf("tokens: %s: %w", n.Path, err)
+			}
+
+			n.Color = c
+			n.structured = sc != nil
+			if sc != nil {
+				n.hex = sc.Hex
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[n] = done
+
+		return nil
+	}
+
+	var err error
+
+	walk(t.Root, func(n *Node) {
+		if err == nil && n.Value != nil {
+			err = visit(n)
+		}
+	})
+
+	return err
+}
+
+// structuredColor is the DTCG object representation of a color
+type structuredColor struct {
+	ColorSpace string        `json:"colorSpace"`
+	Components []interface{} `json:"components"`
+	Alpha      *float64      `json:"alpha,omitempty"`
+	Hex        string        `json:"hex,omitempty"`
+}
+
+// parseColor parses a string or structured color value, returning the structured value when it was one
+func parseColor(raw json.RawMessage) (colors.Color, *structuredColor, error) {
+
+	var s string
+
+	if err := json.Unmarshal(raw, &s); err == nil {
+		c, err := colors.Parse(s)
+		return c, nil, err
+	}
+
+	var sc structuredColor
+
+	if err := json.Unmarshal(raw, &sc); err != nil {
+		return nil, nil, fmt.Errorf("invalid color value: %v", err)
+	}
+
+	alpha := 1.0
+	if sc.Alpha != nil {
+		alpha = *sc.Alpha
+	}
+
+	if space, err := colors.ParseColorSpace(sc.ColorSpace); err == nil && len(sc.Components) == 3 {
+
+		var ch [3]float64
+
+		for i, comp := range sc.Components {
+
+			// the "none" keyword is treated as 0
+			ch[i], _ = comp.(float64)
+		}
+
+		c, err := colors.InSpace(space, ch[0], ch[1], ch[2], alpha)
+
+		return c, &sc, err
+	}
+
+	if sc.Hex != "" {
+
+		hex, err := colors.ParseHEX(sc.Hex)
+		if err != nil {
+			return nil, &sc, err
+		}
+
+		rgb := hex.ToRGB()
+		c, err := colors.RGBA(rgb.R, rgb.G, rgb.B, alpha)
+
+		return c, &sc, err
+	}
+
+	return nil, &sc, fmt.Errorf("unsupported colorSpace %q without a hex fallback", sc.ColorSpace)
+}
+
+// newStructuredColor returns the DTCG object representation of c with the hex fallback, if any
+func newStructuredColor(c colors.Color, hex string) structuredColor {
+
+	rgba := c.ToRGBA()
+	sc := structuredColor{
+		ColorSpace: "srgb",
+		Components: []interface{}{float64(rgba.R) / 255, float64(rgba.G) / 255, float64(rgba.B) / 255},
+		Hex:        hex,
+	}
+
+	if space, ok := c.(*colors.SpaceColor); ok {
+		sc.ColorSpace = space.Space.String()
+		sc.Components = []interface{}{space.R, space.G, space.B}
+	}
+
+	if rgba.A != 1 {
+		sc.Alpha = &rgba.A
+	}
+
+	return sc
+}
+
+func displayPath(path string) string {
+
+	if path == "" {
+		return "root"
+	}
+
+	return path
+}
+
+// Write writes the Tree as an indented DTCG token file. Aliases are written as references, colors read
+// as structured objects are written as objects in their color space, srgb unless the color is a
+// *colors.SpaceColor, with a hex fallback only when the object had one, and all other colors are
+// written using String
+func (t *Tree) Write(w io.Writer) error {
+
+	var buf bytes.Buffer
+
+	if err := writeNode(&buf, t.Root); err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return err
+	}
+
+	out.WriteByte('\n')
+
+	_, err := out.WriteTo(w)
+
+	return err
+}
+
+func writeNode(buf *bytes.Buffer, n *Node) error {
+
+	buf.WriteByte('{')
+	first := true
+
+	field := func(key string, value interface{}) error {
+
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		if !first {
+			buf.WriteByte(',')
+		}
+
+		first = false
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(b)
+
+		return nil
+	}
+
+	if n.Type != "" {
+		if err := field("$type", n.Type); err != nil {
+			return err
+		}
+	}
+
+	if n.Description != "" {
+		if err := field("$description", n.Description); err != nil {
+			return err
+		}
+	}
+
+	if n.IsToken() {
+
+		var value interface{} = n.Value
+
+		switch {
+		case n.Alias != "":
+			value = "{" + n.Alias + "}"
+		case n.Color != nil && n.structured:
+			value = newStructuredColor(n.Color, n.hex)
+		case n.Color != nil:
+			value = n.Color.String()
+		}
+
+		if err := field("$value", value); err != nil {
+			return err
+		}
+	}
+
+	if n.Extensions != nil {
+		if err := field("$extensions", n.Extensions); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range n.Children {
+
+		if !first {
+			buf.WriteByte(',')
+		}
+
+		first = false
+		k, _ := json.Marshal(c.Name)
+		buf.Write(k)
+		buf.WriteByte(':')
+
+		if err := writeNode(buf, c); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteByte('}')
+
+	return nil
+}
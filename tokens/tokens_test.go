@@ -0,0 +1,231 @@
+package tokens
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/colors"
+)
+
+const testTokens = `{
+  "brand": {
+    "$type": "color",
+    "$description": "Brand colors",
+    "primary": {
+      "$value": "#FF0000",
+      "$description": "Main brand color"
+    },
+    "secondary": {
+      "$value": {
+        "colorSpace": "srgb",
+        "components": [0, 0.5, 1],
+        "alpha": 0.5
+      },
+      "$extensions": {"com.example": {"locked": true}}
+    },
+    "accent": {
+      "$value": "{brand.primary}"
+    },
+    "p3": {
+      "$value": {"colorSpace": "display-p3", "components": [1, 0, 0], "hex": "#ff0000"}
+    }
+  },
+  "button": {
+    "background": {
+      "$value": "{brand.accent}"
+    },
+    "radius": {
+      "$type": "dimension",
+      "$value": {"value": 4, "unit": "px"}
+    }
+  }
+}
+`
+
+func TestParse(t *testing.T) {
+
+	tree, err := Parse(strings.NewReader(testTokens))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"brand.primary":     "#ff0000",
+		"brand.secondary":   "color(srgb 0 0.5 1 / 0.5)",
+		"brand.accent":      "#ff0000",
+		"brand.p3":          "color(display-p3 1 0 0)",
+		"button.background": "#ff0000",
+	}
+
+	named := tree.Colors()
+	if len(named) != len(expected) {
+		t.Fatalf("expected %d colors, got %d", len(expected), len(named))
+	}
+
+	for _, n := range named {
+		if n.Color.String() != expected[n.Name] {
+			t.Errorf("%s: expected %s, got %s", n.Name, expected[n.Name], n.Color.String())
+		}
+	}
+
+	bg := tree.Lookup("button.background")
+	if bg.Alias != "brand.accent" || bg.ResolvedType() != TypeColor || bg.Type != "" {
+		t.Errorf("unexpected alias resolution %+v", bg)
+	}
+
+	radius := tree.Lookup("button.radius")
+	if radius.Color != nil || radius.ResolvedType() != "dimension" || !radius.IsToken() {
+		t.Errorf("unexpected dimension token %+v", radius)
+	}
+
+	if tree.Lookup("brand").IsToken() || tree.Lookup("brand").Description != "Brand colors" {
+		t.Error("expected brand to be a described group")
+	}
+
+	if tree.Lookup("missing") != nil {
+		t.Error("expected nil for a missing path")
+	}
+}
+
+func TestWrite(t *testing.T) {
+
+	tree, err := Parse(strings.NewReader(testTokens))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err = tree.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+
+	for _, s := range []string{
+		`"$value": "#ff0000"`,
+		`"$value": "{brand.primary}"`,
+		`"colorSpace": "srgb"`,
+		`"alpha": 0.5`,
+		`"hex": "#ff0000"`,
+		`"colorSpace": "display-p3"`,
+		`"locked": true`,
+		`"unit": "px"`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected output to contain %s\n%s", s, out)
+		}
+	}
+
+	if strings.Count(out, `"hex"`) != 1 {
+		t.Errorf("expected only the hex fallback of the file\n%s", out)
+	}
+
+	if strings.Index(out, `"primary"`) > strings.Index(out, `"secondary"`) {
+		t.Error("expected file order to be preserved")
+	}
+
+	again, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(again.Colors()) != len(tree.Colors()) {
+		t.Error("expected round trip to preserve colors")
+	}
+
+	built := New()
+	red, _ := colors.ParseHEX("#f00")
+	built.SetColor("theme.error", red)
+	built.SetColor("theme.error", red)
+
+	buf.Reset()
+	if err = built.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != "{\n  \"theme\": {\n    \"error\": {\n      \"$type\": \"color\",\n      \"$value\": \"#f00\"\n    }\n  }\n}\n" {
+		t.Errorf("unexpected output\n%s", buf.String())
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+
+	const file = `{
+  "brand": {
+    "$type": "color",
+    "primary": {
+      "$value": {
+        "colorSpace": "srgb",
+        "components": [
+          0.1,
+          0.5,
+          0.3
+        ]
+      }
+    },
+    "secondary": {
+      "$value": {
+        "colorSpace": "srgb",
+        "components": [
+          1,
+          0.5,
+          0
+        ],
+        "alpha": 0.25,
+        "hex": "#ff8000"
+      }
+    }
+  }
+}
+`
+
+	tree, err := Parse(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err = tree.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != file {
+		t.Errorf("expected the file to be written back unchanged\n%s", buf.String())
+	}
+
+	tree.SetColor("brand.secondary", &colors.SpaceColor{Space: colors.SpaceSRGB, R: 0, G: 0, B: 1, A: 1})
+
+	buf.Reset()
+	if err = tree.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), `"hex": "#0000ff"`) {
+		t.Errorf("expected the hex fallback to follow the new color\n%s", buf.String())
+	}
+}
+
+func TestAliasErrors(t *testing.T) {
+
+	_, err := Parse(strings.NewReader(`{"$type":"color","a":{"$value":"{b}"},"b":{"$value":"{c}"},"c":{"$value":"{a}"}}`))
+	if !errors.Is(err, ErrAliasCycle) || err.Error() != "tokens: alias cycle: a -> b -> c -> a" {
+		t.Errorf("expected alias cycle, got %v", err)
+	}
+
+	_, err = Parse(strings.NewReader(`{"a":{"$type":"color","$value":"{missing}"}}`))
+	if !errors.Is(err, ErrUnknownAlias) {
+		t.Errorf("expected unknown alias, got %v", err)
+	}
+
+	_, err = Parse(strings.NewReader(`{"a":{"$type":"color","$value":"not a color"}}`))
+	if !errors.Is(err, colors.ErrBadColor) {
+		t.Errorf("expected bad color, got %v", err)
+	}
+
+	_, err = Parse(strings.NewReader(`{"a":{"$type":"color","$value":{"colorSpace":"lab","components":[50,0,0]}}}`))
+	if err == nil || err.Error() != `tokens: a: unsupported colorSpace "lab" without a hex fallback` {
+		t.Errorf("expected unsupported colorSpace, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package colors
+
+import "math"
+
+// xtermBase16 are xterm's default values for the 16 basic terminal colors
+var xtermBase16 = [16]RGBColor{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// xtermCubeLevels are the channel values of the xterm 6x6x6 color cube
+var xtermCubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+var (
+	xterm256 [256]RGBColor
+
+	// xtermPoints are the xterm colors converted to the color space of each DistanceMetric
+	xtermPoints [DistanceCIEDE2000 + 1][256][3]float64
+)
+
+func init() {
+
+	copy(xterm256[:], xtermBase16[:])
+
+	for i := 0; i < 216; i++ {
+		xterm256[16+i] = RGBColor{R: xtermCubeLevels[i/36], G: xtermCubeLevels[i/6%6], B: xtermCubeLevels[i%6]}
+	}
+
+	for i := 0; i < 24; i++ {
+		v := uint8(8 + i*10)
+		xterm256[232+i] = RGBColor{R: v, G: v, B: v}
+	}
+
+	for m := range xtermPoints {
+		for i, c := range xterm256 {
+			xtermPoints[m][i] = DistanceMetric(m).point(float64(c.R), float64(c.G), float64(c.B))
+		}
+	}
+}
+
+// nearestXTerm returns the index, within [first, last], of the xterm color nearest to c using the metric
+func nearestXTerm(c *RGBColor, first, last uint8, m DistanceMetric) uint8 {
+
+	if int(m) >= len(xtermPoints) {
+		m = DistanceRGB
+	}
+
+	p := m.point(float64(c.R), float64(c.G), float64(c.B))
+	best, bestDist := int(first), math.Inf(1)
+
+	for i := int(first); i <= int(last); i++ {
+		if d := m.between(p, xtermPoints[m][i]); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+
+	return uint8(best)
+}
+
+// XTermPalette is a contiguous range of the xterm 256 color palette along with the
+// distance metric used to find the nearest entry to a color
+type XTermPalette struct {
+	first  uint8
+	last   uint8
+	metric DistanceMetric
+}
+
+// xterm palettes, all using DistanceOKLab
+var (
+	// XTerm256 is the complete xterm 256 color palette
+	XTerm256 = XTermPalette{first: 0, last: 255, metric: DistanceOKLab}
+
+	// XTermBase16 is the 8 basic and 8 bright colors, indexes 0-15, using xterm's default values
+	XTermBase16 = XTermPalette{first: 0, last: 15, metric: DistanceOKLab}
+
+	// XTermCube is the 6x6x6 color cube, indexes 16-231
+	XTermCube = XTermPalette{first: 16, last: 231, metric: DistanceOKLab}
+
+	// XTermGrayscale is the 24 step grayscale ramp, indexes 232-255
+	XTermGrayscale = XTermPalette{first: 232, last: 255, metric: DistanceOKLab}
+)
+
+// FromXTermIndex returns the color at index i of the xterm 256 color palette
+func FromXTermIndex(i uint8) *RGBColor {
+	c := xterm256[i]
+	return &c
+}
+
+// WithMetric returns a copy of the palette that uses m to find the nearest entry to a color,
+// an unknown metric being replaced by DistanceRGB as Distance treats it
+func (p XTermPalette) WithMetric(m DistanceMetric) XTermPalette {
+
+	if m > DistanceCIEDE2000 {
+		m = DistanceRGB
+	}
+
+	p.metric = m
+
+	return p
+}
+
+// Metric returns the distance metric used to find the nearest entry to a color
+func (p XTermPalette) Metric() DistanceMetric {
+	return p.metric
+}
+
+// Index returns the xterm index of the palette entry nearest to c
+func (p XTermPalette) Index(c Color) uint8 {
+	return nearestXTerm(c.ToRGB(), p.first, p.last, p.metric)
+}
+
+// Nearest returns the palette entry nearest to c
+func (p XTermPalette) Nearest(c Color) *RGBColor {
+	return FromXTermIndex(p.Index(c))
+}
+
+// Contains reports whether the xterm index i is part of the palette
+func (p XTermPalette) Contains(i uint8) bool {
+	return i >= p.first && i <= p.last
+}
+
+// Len returns the number of colors in the palette
+func (p XTermPalette) Len() int {
+	return int(p.last) - int(p.first) + 1
+}
+
+// Colors returns the colors of the palette ordered by xterm index
+func (p XTermPalette) Colors() []Color {
+
+	colors := make([]Color, 0, p.Len())
+
+	for i := int(p.first); i <= int(p.last); i++ {
+		colors = append(colors, FromXTermIndex(uint8(i)))
+	}
+
+	return colors
+}
@@ -0,0 +1,44 @@
+package colors
+
+import "testing"
+
+func TestXTerm(t *testing.T) {
+
+	Equal(t, FromXTermIndex(0).String(), "rgb(0,0,0)")
+	Equal(t, FromXTermIndex(9).String(), "rgb(255,0,0)")
+	Equal(t, FromXTermIndex(16).String(), "rgb(0,0,0)")
+	Equal(t, FromXTermIndex(208).String(), "rgb(255,135,0)")
+	Equal(t, FromXTermIndex(231).String(), "rgb(255,255,255)")
+	Equal(t, FromXTermIndex(232).String(), "rgb(8,8,8)")
+	Equal(t, FromXTermIndex(255).String(), "rgb(238,238,238)")
+
+	orange, _ := ParseHEX("#ff8800")
+	gray, _ := RGB(100, 100, 100)
+
+	Equal(t, XTerm256.Index(orange), uint8(208))
+	Equal(t, XTermCube.Index(orange), uint8(208))
+	Equal(t, XTermBase16.Index(orange), uint8(9))
+	Equal(t, XTermGrayscale.Index(gray), uint8(241))
+	Equal(t, XTermCube.Index(gray), uint8(59))
+	Equal(t, XTermGrayscale.Nearest(gray).String(), "rgb(98,98,98)")
+
+	for _, m := range []DistanceMetric{DistanceRGB, DistanceOKLab, DistanceCIEDE2000} {
+
+		p := XTerm256.WithMetric(m)
+		Equal(t, p.Metric(), m)
+
+		for i := 16; i < 256; i++ {
+			idx := p.Index(FromXTermIndex(uint8(i)))
+			Equal(t, FromXTermIndex(idx).Equal(FromXTermIndex(uint8(i))), true)
+		}
+	}
+
+	Equal(t, XTerm256.Metric(), DistanceOKLab)
+	Equal(t, XTerm256.WithMetric(DistanceMetric(100)).Metric(), DistanceRGB)
+	Equal(t, XTerm256.Len(), 256)
+	Equal(t, XTermCube.Len(), 216)
+	Equal(t, XTermGrayscale.Len(), 24)
+	Equal(t, len(XTermBase16.Colors()), 16)
+	Equal(t, XTermCube.Contains(16), true)
+	Equal(t, XTermCube.Contains(232), false)
+}